@@ -0,0 +1,149 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/mehmetcantas/medium-cli/components/section"
+	"github.com/mehmetcantas/medium-cli/config"
+	"github.com/mehmetcantas/medium-cli/pkg"
+	"github.com/mehmetcantas/medium-cli/pkg/output"
+	"github.com/mehmetcantas/medium-cli/ui"
+)
+
+// Exit codes of the commands.
+const (
+	exitOK       = 0
+	exitError    = 1
+	exitUsage    = 2
+	exitNotFound = 3
+)
+
+const (
+	listUsage = "usage: medium-cli [--config path] list [--output format] <section>"
+	getUsage  = "usage: medium-cli [--config path] get [--output format] <section> <id>"
+)
+
+// runCommand runs the list or get command, logging to debug.log when debug is
+// set, and returns the process exit code.
+func runCommand(name string, options ui.Options, args []string, debug bool) int {
+	if logger := openLogger(debug); logger != nil {
+		defer logger.Close()
+	}
+
+	if name == "get" {
+		return runGetCommand(options, args)
+	}
+	return runListCommand(options, args)
+}
+
+// runListCommand handles `medium-cli list <section>`, printing the rows the
+// section loads at start, and returns the process exit code.
+func runListCommand(options ui.Options, args []string) int {
+	format, positional, ok := parseCommandFlags("list", listUsage, args, 1)
+	if !ok {
+		return exitUsage
+	}
+
+	loaded, err := ui.LoadSection(options, positional[0], nil)
+	if err != nil {
+		printError(err)
+		return exitError
+	}
+
+	records, rows := loaded.GetShownRows()
+	if records == nil {
+		records = []interface{}{}
+	}
+	if err := output.Write(os.Stdout, format, loaded.GetSectionColumns(), rows, records); err != nil {
+		printError(err)
+		return exitError
+	}
+	return exitOK
+}
+
+// runGetCommand handles `medium-cli get <section> <id>`, printing the record
+// with the given id, and returns the process exit code. Pages are loaded until
+// the record is found.
+func runGetCommand(options ui.Options, args []string) int {
+	format, positional, ok := parseCommandFlags("get", getUsage, args, 2)
+	if !ok {
+		return exitUsage
+	}
+	title, id := positional[0], positional[1]
+
+	loaded, err := ui.LoadSection(options, title, func(loaded section.Section) bool {
+		records, _ := loaded.GetShownRows()
+		return findRecord(records, id) < 0
+	})
+	if err != nil {
+		printError(err)
+		return exitError
+	}
+
+	records, rows := loaded.GetShownRows()
+	i := findRecord(records, id)
+	if i < 0 {
+		fmt.Fprintf(os.Stderr, "no row with id %q in section %q\n", id, title)
+		return exitNotFound
+	}
+	if err := output.Write(os.Stdout, format, loaded.GetSectionColumns(), rows[i:i+1], records[i]); err != nil {
+		printError(err)
+		return exitError
+	}
+	return exitOK
+}
+
+// parseCommandFlags parses the flags of a command followed by numArgs
+// arguments, printing the usage when they are wrong.
+func parseCommandFlags(name string, usage string, args []string, numArgs int) (output.Format, []string, bool) {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprintln(os.Stderr, usage)
+		flags.PrintDefaults()
+	}
+	formatName := flags.String("output", string(output.Table), "print the rows as json, yaml, csv, tsv, table or markdown")
+	if err := flags.Parse(args); err != nil {
+		return "", nil, false
+	}
+	if flags.NArg() != numArgs {
+		flags.Usage()
+		return "", nil, false
+	}
+
+	format, err := output.ParseFormat(*formatName)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return "", nil, false
+	}
+	return format, flags.Args(), true
+}
+
+// findRecord returns the index of the record whose id field is id, or -1.
+func findRecord(records []interface{}, id string) int {
+	for i, record := range records {
+		if recordId, ok := pkg.RecordId(record); ok && recordId == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// printError prints err to stderr, a validation error with one line per
+// problem.
+func printError(err error) {
+	var validationErr config.ValidationError
+	if !errors.As(err, &validationErr) {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+
+	lines := make([]string, 0, len(validationErr.Problems))
+	for _, problem := range validationErr.Problems {
+		lines = append(lines, fmt.Sprintf("%s: %s", problem.Location(validationErr.File), problem.Message))
+	}
+	fmt.Fprintln(os.Stderr, strings.Join(lines, "\n"))
+}
@@ -0,0 +1,45 @@
+package commentsection
+
+import (
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mehmetcantas/medium-cli/components/table"
+	"github.com/mehmetcantas/medium-cli/pkg"
+)
+
+type Comment struct {
+	Data  CommentModel
+	Width int
+}
+
+type CommentModel struct {
+	PostId int    `json:"postId"`
+	Id     int    `json:"id"`
+	Name   string `json:"name"`
+	Email  string `json:"email"`
+	Body   string `json:"body"`
+}
+
+func (c *Comment) ToTableRow() table.Row {
+	return table.Row{
+		c.renderId(),
+		c.renderName(),
+		c.renderEmail(),
+		c.renderPostId(),
+	}
+}
+
+func (c *Comment) renderId() string {
+	return lipgloss.NewStyle().Render(pkg.CastIntToStr(c.Data.Id))
+}
+
+func (c *Comment) renderName() string {
+	return lipgloss.NewStyle().Render(c.Data.Name)
+}
+
+func (c *Comment) renderEmail() string {
+	return lipgloss.NewStyle().Faint(true).Render(c.Data.Email)
+}
+
+func (c *Comment) renderPostId() string {
+	return lipgloss.NewStyle().Render(pkg.CastIntToStr(c.Data.PostId))
+}
@@ -0,0 +1,58 @@
+package commentsection
+
+import (
+	"github.com/mehmetcantas/medium-cli/components/placeholdersection"
+	"github.com/mehmetcantas/medium-cli/components/section"
+	"github.com/mehmetcantas/medium-cli/components/table"
+	"github.com/mehmetcantas/medium-cli/config"
+	"github.com/mehmetcantas/medium-cli/ui/screencontext"
+)
+
+const SectionType = "comments"
+
+var (
+	idCellWidth    = 8
+	emailCellWidth = 30
+	growCell       = true
+)
+
+var kind = section.Kind{
+	Type:       SectionType,
+	ItemLabel:  "Comment",
+	EmptyState: "No comments found",
+}
+
+type source struct {
+	placeholdersection.Fetcher
+}
+
+func NewModel(id int, ctx *screencontext.ScreenContext, config config.SectionConfig, view config.ViewType) section.Model {
+	fetcher := placeholdersection.NewFetcher(ctx, config, func() interface{} { return &[]CommentModel{} })
+	return section.NewModel(id, ctx, config, view, kind, source{fetcher})
+}
+
+func (s source) Columns() []table.Column {
+	return []table.Column{
+		{
+			Title: "ID",
+			Width: &idCellWidth,
+		},
+		{
+			Title: "Name",
+			Grow:  &growCell,
+		},
+		{
+			Title: "Email",
+			Width: &emailCellWidth,
+		},
+		{
+			Title: "Post ID",
+			Width: &idCellWidth,
+		},
+	}
+}
+
+func (s source) BuildRow(record interface{}, width int) table.Row {
+	commentModel := Comment{Data: record.(CommentModel), Width: width}
+	return commentModel.ToTableRow()
+}
@@ -0,0 +1,114 @@
+package export
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mehmetcantas/medium-cli/components/help"
+	"github.com/mehmetcantas/medium-cli/ui/screencontext"
+)
+
+var (
+	promptStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("#3498db"))
+
+	exportStyle = lipgloss.NewStyle().
+			Height(help.FooterHeight - 1).
+			BorderTop(true).
+			BorderStyle(lipgloss.NormalBorder()).
+			BorderForeground(lipgloss.Color("#3498db"))
+
+	hintStyle = lipgloss.NewStyle().Faint(true)
+
+	problemStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#e74c3c"))
+)
+
+// Model is the prompt shown in place of the help for the path the rows of the
+// current section are exported to.
+type Model struct {
+	IsEditing bool
+	input     textinput.Model
+	// problem tells why the typed path was refused, until it is edited.
+	problem string
+}
+
+func NewModel() Model {
+	input := textinput.New()
+	input.Prompt = "Export to: "
+	input.PromptStyle = promptStyle
+
+	return Model{
+		IsEditing: false,
+		input:     input,
+	}
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	var cmd tea.Cmd
+	if _, ok := msg.(tea.KeyMsg); ok {
+		m.problem = ""
+	}
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+// Open starts editing path, the file to export to.
+func (m *Model) Open(path string) tea.Cmd {
+	m.IsEditing = true
+	m.problem = ""
+	m.input.SetValue(path)
+	m.input.CursorEnd()
+	return m.input.Focus()
+}
+
+func (m *Model) Close() {
+	m.IsEditing = false
+	m.input.Blur()
+}
+
+// Refuse opens the prompt again on path, telling why it was refused.
+func (m *Model) Refuse(path string, problem string) tea.Cmd {
+	cmd := m.Open(path)
+	m.problem = problem
+	return cmd
+}
+
+// Value returns the path typed in the prompt.
+func (m Model) Value() string {
+	return strings.TrimSpace(m.input.Value())
+}
+
+func (m Model) View(ctx screencontext.ScreenContext) string {
+	hint := hintStyle.Render(fmt.Sprintf("enter to export as .csv, .json or .md · %s to cancel", ctx.Keys.Back.Help().Key))
+	if m.problem != "" {
+		hint = problemStyle.Render(m.problem) + hintStyle.Render(fmt.Sprintf(" · %s to cancel", ctx.Keys.Back.Help().Key))
+	}
+	content := lipgloss.JoinVertical(lipgloss.Left, m.input.View(), hint)
+	return exportStyle.Copy().Width(ctx.ScreenWidth).MaxWidth(ctx.ScreenWidth).Render(content)
+}
+
+// DefaultFileName returns the name of a CSV file for the rows of the section
+// titled title exported at now, e.g. people-20060102-150405.csv.
+func DefaultFileName(title string, now time.Time) string {
+	name := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return '-'
+	}, title)
+	name = strings.Trim(name, "-")
+	for strings.Contains(name, "--") {
+		name = strings.ReplaceAll(name, "--", "-")
+	}
+	if name == "" {
+		name = "export"
+	}
+
+	return fmt.Sprintf("%s-%s.csv", name, now.Format("20060102-150405"))
+}
@@ -0,0 +1,202 @@
+package feedsection
+
+import (
+	"encoding/xml"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mehmetcantas/medium-cli/components/table"
+)
+
+// pubDateLayouts are the date formats seen in the pubDate of RSS items and
+// the dates of Atom entries.
+var pubDateLayouts = []string{time.RFC1123, time.RFC1123Z, time.RFC3339}
+
+type Post struct {
+	Data  PostModel
+	Width int
+}
+
+type PostModel struct {
+	Title       string    `json:"title"`
+	Author      string    `json:"author"`
+	Published   time.Time `json:"published"`
+	Categories  []string  `json:"categories"`
+	Link        string    `json:"link"`
+	ContentHTML string    `json:"contentHTML"`
+}
+
+type rssFeed struct {
+	Channel struct {
+		Items []rssItem `xml:"item"`
+	} `xml:"channel"`
+}
+
+type rssItem struct {
+	Title       string   `xml:"title"`
+	Link        string   `xml:"link"`
+	Creator     string   `xml:"http://purl.org/dc/elements/1.1/ creator"`
+	PubDate     string   `xml:"pubDate"`
+	Categories  []string `xml:"category"`
+	Content     string   `xml:"http://purl.org/rss/1.0/modules/content/ encoded"`
+	Description string   `xml:"description"`
+}
+
+type atomFeed struct {
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomEntry struct {
+	Title string `xml:"title"`
+	Links []struct {
+		Href string `xml:"href,attr"`
+		Rel  string `xml:"rel,attr"`
+	} `xml:"link"`
+	Authors []struct {
+		Name string `xml:"name"`
+	} `xml:"author"`
+	Published  string `xml:"published"`
+	Updated    string `xml:"updated"`
+	Categories []struct {
+		Term string `xml:"term,attr"`
+	} `xml:"category"`
+	Content string `xml:"content"`
+	Summary string `xml:"summary"`
+}
+
+// decodeFeed parses an RSS or Atom document into result, which must be a
+// *[]PostModel.
+func decodeFeed(data []byte, result interface{}) error {
+	var root struct {
+		XMLName xml.Name
+	}
+	if err := xml.Unmarshal(data, &root); err != nil {
+		return err
+	}
+
+	var posts []PostModel
+	var err error
+	if root.XMLName.Local == "feed" {
+		posts, err = decodeAtom(data)
+	} else {
+		posts, err = decodeRSS(data)
+	}
+	if err != nil {
+		return err
+	}
+
+	*result.(*[]PostModel) = posts
+	return nil
+}
+
+func decodeRSS(data []byte) ([]PostModel, error) {
+	var feed rssFeed
+	if err := xml.Unmarshal(data, &feed); err != nil {
+		return nil, err
+	}
+
+	posts := make([]PostModel, 0, len(feed.Channel.Items))
+	for _, item := range feed.Channel.Items {
+		content := item.Content
+		if content == "" {
+			content = item.Description
+		}
+		posts = append(posts, PostModel{
+			Title:       strings.TrimSpace(item.Title),
+			Author:      strings.TrimSpace(item.Creator),
+			Published:   parsePubDate(item.PubDate),
+			Categories:  item.Categories,
+			Link:        strings.TrimSpace(item.Link),
+			ContentHTML: content,
+		})
+	}
+	return posts, nil
+}
+
+func decodeAtom(data []byte) ([]PostModel, error) {
+	var feed atomFeed
+	if err := xml.Unmarshal(data, &feed); err != nil {
+		return nil, err
+	}
+
+	posts := make([]PostModel, 0, len(feed.Entries))
+	for _, entry := range feed.Entries {
+		var authors, categories []string
+		for _, author := range entry.Authors {
+			authors = append(authors, strings.TrimSpace(author.Name))
+		}
+		for _, category := range entry.Categories {
+			categories = append(categories, category.Term)
+		}
+		var link string
+		for _, entryLink := range entry.Links {
+			if entryLink.Rel == "" || entryLink.Rel == "alternate" {
+				link = strings.TrimSpace(entryLink.Href)
+				break
+			}
+		}
+		published := entry.Published
+		if published == "" {
+			published = entry.Updated
+		}
+		content := entry.Content
+		if content == "" {
+			content = entry.Summary
+		}
+		posts = append(posts, PostModel{
+			Title:       strings.TrimSpace(entry.Title),
+			Author:      strings.Join(authors, ", "),
+			Published:   parsePubDate(published),
+			Categories:  categories,
+			Link:        link,
+			ContentHTML: content,
+		})
+	}
+	return posts, nil
+}
+
+func parsePubDate(pubDate string) time.Time {
+	for _, layout := range pubDateLayouts {
+		if published, err := time.Parse(layout, strings.TrimSpace(pubDate)); err == nil {
+			return published
+		}
+	}
+	return time.Time{}
+}
+
+func (p *Post) ToTableRow() table.Row {
+	return table.Row{
+		p.renderTitle(),
+		p.renderAuthor(),
+		p.renderPublished(),
+		p.renderCategories(),
+	}
+}
+
+func (p *Post) renderTitle() string {
+	return lipgloss.NewStyle().Render(p.Data.Title)
+}
+
+func (p *Post) renderAuthor() string {
+	return lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "#42A0FA", Dark: "#42A0FA"}).Render(p.Data.Author)
+}
+
+func (p *Post) renderPublished() string {
+	if p.Data.Published.IsZero() {
+		return ""
+	}
+	return lipgloss.NewStyle().Faint(true).Render(p.Data.Published.Local().Format("2006-01-02"))
+}
+
+func (p *Post) renderCategories() string {
+	return lipgloss.NewStyle().Faint(true).Render(strings.Join(p.Data.Categories, ", "))
+}
+
+func (p PostModel) GetTitle() string {
+	return p.Title
+}
+
+func (p PostModel) GetContentHTML() string {
+	return p.ContentHTML
+}
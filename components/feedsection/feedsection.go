@@ -0,0 +1,88 @@
+package feedsection
+
+import (
+	"context"
+
+	"github.com/mehmetcantas/medium-cli/components/placeholdersection"
+	"github.com/mehmetcantas/medium-cli/components/section"
+	"github.com/mehmetcantas/medium-cli/components/table"
+	"github.com/mehmetcantas/medium-cli/config"
+	"github.com/mehmetcantas/medium-cli/ui/screencontext"
+)
+
+const SectionType = "feed"
+
+var (
+	authorCellWidth     = 16
+	publishedCellWidth  = 12
+	categoriesCellWidth = 24
+	growCell            = true
+)
+
+var kind = section.Kind{
+	Type:       SectionType,
+	ItemLabel:  "Post",
+	EmptyState: "No posts found",
+}
+
+type source struct {
+	placeholdersection.Fetcher
+	// limit caps the number of posts, zero to show them all.
+	limit int
+}
+
+func NewModel(id int, ctx *screencontext.ScreenContext, config config.SectionConfig, view config.ViewType) section.Model {
+	return section.NewModel(id, ctx, config, view, kind, newSource(ctx, config))
+}
+
+func newSource(ctx *screencontext.ScreenContext, config config.SectionConfig) source {
+	fetcher := placeholdersection.NewFetcher(ctx, config, func() interface{} { return &[]PostModel{} })
+	fetcher.Decode = decodeFeed
+	// Feeds have no pages, the page size only caps the number of posts.
+	fetcher.Unpaged = true
+	return source{Fetcher: fetcher, limit: config.GetPageSize()}
+}
+
+func (s source) Columns() []table.Column {
+	return []table.Column{
+		{
+			Title: "Title",
+			Grow:  &growCell,
+		},
+		{
+			Title: "Author",
+			Width: &authorCellWidth,
+		},
+		{
+			Title: "Published",
+			Width: &publishedCellWidth,
+		},
+		{
+			Title: "Categories",
+			Width: &categoriesCellWidth,
+		},
+	}
+}
+
+func (s source) BuildRow(record interface{}, width int) table.Row {
+	postModel := Post{Data: record.(PostModel), Width: width}
+	return postModel.ToTableRow()
+}
+
+func (s source) Fetch(ctx context.Context, request section.FetchRequest, onStale func(section.Page)) (section.Page, error) {
+	var onStaleLimited func(page section.Page)
+	if onStale != nil {
+		onStaleLimited = func(page section.Page) { onStale(s.limitPage(page)) }
+	}
+
+	page, err := s.Fetcher.Fetch(ctx, request, onStaleLimited)
+	return s.limitPage(page), err
+}
+
+func (s source) limitPage(page section.Page) section.Page {
+	if s.limit > 0 && len(page.Records) > s.limit {
+		page.Records = page.Records[:s.limit]
+	}
+	page.Total = -1
+	return page
+}
@@ -0,0 +1,191 @@
+package feedsection
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/mehmetcantas/medium-cli/components/section"
+	"github.com/mehmetcantas/medium-cli/config"
+	"github.com/mehmetcantas/medium-cli/ui/screencontext"
+)
+
+// newFeedServer serves the fixtures of testdata.
+func newFeedServer(t *testing.T) *httptest.Server {
+	server := httptest.NewServer(http.FileServer(http.Dir("testdata")))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// newTestSource returns the source of a feed section reading filters from
+// server.
+func newTestSource(server *httptest.Server, filters string, limit int) source {
+	ctx := &screencontext.ScreenContext{
+		Config:  &config.Config{},
+		Sources: map[string]config.Source{config.MediumSourceName: {Name: config.MediumSourceName, BaseURL: server.URL}},
+	}
+	sectionConfig := config.SectionConfig{Title: "Feed", Type: config.FeedSection, Filters: filters}
+	if limit > 0 {
+		sectionConfig.Limit = &limit
+	}
+	return newSource(ctx, sectionConfig)
+}
+
+func fetchPosts(t *testing.T, s source) []PostModel {
+	page, err := s.Fetch(context.Background(), section.FetchRequest{Filter: section.NewFilter(s.Config)}, nil)
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	posts := make([]PostModel, 0, len(page.Records))
+	for _, record := range page.Records {
+		posts = append(posts, record.(PostModel))
+	}
+	return posts
+}
+
+func TestFetchFeed(t *testing.T) {
+	tests := []struct {
+		name    string
+		fixture string
+		want    []PostModel
+	}{
+		{
+			name:    "RSS 2.0",
+			fixture: "medium.rss",
+			want: []PostModel{
+				{
+					Title:       "Writing terminal UIs in Go",
+					Author:      "Jane Doe",
+					Published:   time.Date(2023, 1, 2, 15, 4, 5, 0, time.UTC),
+					Categories:  []string{"golang", "terminal"},
+					Link:        "https://medium.com/@janedoe/writing-terminal-uis-in-go-1a2b3c?source=rss-0123456789ab------2",
+					ContentHTML: "<h3>Writing terminal UIs in Go</h3><p>Bubble Tea makes it easy.</p>",
+				},
+				{
+					Title:       "Caching HTTP responses",
+					Author:      "Jane Doe",
+					Published:   time.Date(2022, 12, 16, 7, 30, 0, 0, time.UTC),
+					Categories:  []string{"http"},
+					Link:        "https://medium.com/@janedoe/caching-http-responses-4d5e6f?source=rss-0123456789ab------2",
+					ContentHTML: "<p>ETags and Last-Modified.</p>",
+				},
+				{
+					Title:       "Retrying with backoff",
+					Author:      "John Roe",
+					Published:   time.Date(2022, 11, 30, 12, 0, 0, 0, time.UTC),
+					Categories:  []string{"golang", "http"},
+					Link:        "https://medium.com/@janedoe/retrying-with-backoff-7a8b9c?source=rss-0123456789ab------2",
+					ContentHTML: "<p>Jitter matters.</p>",
+				},
+			},
+		},
+		{
+			name:    "Atom",
+			fixture: "blog.atom",
+			want: []PostModel{
+				{
+					Title:       "Atom feeds are still around",
+					Author:      "Ada Lovelace",
+					Published:   time.Date(2023, 2, 1, 9, 0, 0, 0, time.UTC),
+					Categories:  []string{"feeds", "xml"},
+					Link:        "https://blog.example.com/atom-feeds",
+					ContentHTML: "<p>Not everything is RSS.</p>",
+				},
+				{
+					Title:       "Only updated",
+					Author:      "Charles Babbage",
+					Published:   time.Date(2023, 1, 15, 16, 30, 0, 0, time.UTC),
+					Link:        "https://blog.example.com/only-updated",
+					ContentHTML: "No published date.",
+				},
+			},
+		},
+	}
+
+	server := newFeedServer(t)
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			posts := fetchPosts(t, newTestSource(server, test.fixture, 0))
+			if len(posts) != len(test.want) {
+				t.Fatalf("got %d posts, want %d", len(posts), len(test.want))
+			}
+			for i, post := range posts {
+				want := test.want[i]
+				if !post.Published.Equal(want.Published) {
+					t.Errorf("post %d published at %v, want %v", i, post.Published, want.Published)
+				}
+				post.Published, want.Published = time.Time{}, time.Time{}
+				if !reflect.DeepEqual(post, want) {
+					t.Errorf("post %d = %+v, want %+v", i, post, want)
+				}
+			}
+		})
+	}
+}
+
+func TestFetchFeedLimitAndFilter(t *testing.T) {
+	tests := []struct {
+		name       string
+		filters    string
+		limit      int
+		wantTitles []string
+	}{
+		{
+			name:       "limit",
+			filters:    "medium.rss",
+			limit:      2,
+			wantTitles: []string{"Writing terminal UIs in Go", "Caching HTTP responses"},
+		},
+		{
+			name:       "limit above the number of posts",
+			filters:    "medium.rss",
+			limit:      10,
+			wantTitles: []string{"Writing terminal UIs in Go", "Caching HTTP responses", "Retrying with backoff"},
+		},
+		{
+			name:       "filter on categories",
+			filters:    "medium.rss categories:http",
+			wantTitles: []string{"Caching HTTP responses", "Retrying with backoff"},
+		},
+		{
+			name:       "filter on author",
+			filters:    `medium.rss author:"John Roe"`,
+			wantTitles: []string{"Retrying with backoff"},
+		},
+		{
+			name:       "filter before the limit",
+			filters:    "medium.rss -author:Jane",
+			limit:      1,
+			wantTitles: []string{"Retrying with backoff"},
+		},
+		{
+			name:       "filter on the published date",
+			filters:    "blog.atom published>=2023-02-01",
+			wantTitles: []string{"Atom feeds are still around"},
+		},
+	}
+
+	server := newFeedServer(t)
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			posts := fetchPosts(t, newTestSource(server, test.filters, test.limit))
+			var titles []string
+			for _, post := range posts {
+				titles = append(titles, post.Title)
+			}
+			if !reflect.DeepEqual(titles, test.wantTitles) {
+				t.Errorf("got posts %q, want %q", titles, test.wantTitles)
+			}
+		})
+	}
+}
+
+func TestDecodeFeedInvalid(t *testing.T) {
+	var posts []PostModel
+	if err := decodeFeed([]byte("<rss><channel>"), &posts); err == nil {
+		t.Error("decodeFeed() of a truncated document succeeded, want an error")
+	}
+}
@@ -1,6 +1,8 @@
 package help
 
 import (
+	"time"
+
 	bbHelp "github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
@@ -13,16 +15,53 @@ var (
 	blue         = lipgloss.AdaptiveColor{Light: "#3498db", Dark: "#2980b9"}
 	FooterHeight = 3
 
-	helpTextStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#3498db"))
-	helpStyle     = lipgloss.NewStyle().
-			Height(FooterHeight - 1).
-			BorderTop(true).
-			BorderStyle(lipgloss.NormalBorder()).
-			BorderForeground(lipgloss.Color("#3498db"))
+	helpTextStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("#3498db"))
+	statusStyle      = lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "#2980b9", Dark: "#E2E1ED"})
+	statusErrorStyle = lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "#c0392b", Dark: "#e74c3c"})
+	helpStyle        = lipgloss.NewStyle().
+				Height(FooterHeight - 1).
+				BorderTop(true).
+				BorderStyle(lipgloss.NormalBorder()).
+				BorderForeground(lipgloss.Color("#3498db"))
 )
 
+// statusDuration is how long a status message stays in the footer.
+const statusDuration = 4 * time.Second
+
 type Model struct {
-	help bbHelp.Model
+	help          bbHelp.Model
+	status        string
+	statusIsError bool
+	statusId      int
+	// actions are the keys of the current section's actions.
+	actions []key.Binding
+}
+
+// keyMap adds the actions of the current section to the app's keys.
+type keyMap struct {
+	pkg.KeyMap
+	actions []key.Binding
+}
+
+func (k keyMap) ShortHelp() []key.Binding {
+	return append(k.actions, k.KeyMap.ShortHelp()...)
+}
+
+func (k keyMap) FullHelp() [][]key.Binding {
+	// The actions come first as the help is cut at the screen's width.
+	var groups [][]key.Binding
+	for i := 0; i < len(k.actions); i += 2 {
+		end := i + 2
+		if end > len(k.actions) {
+			end = len(k.actions)
+		}
+		groups = append(groups, k.actions[i:end])
+	}
+	return append(groups, k.KeyMap.FullHelp()...)
+}
+
+type clearStatusMsg struct {
+	statusId int
 }
 
 func NewModel() Model {
@@ -45,9 +84,9 @@ func NewModel() Model {
 
 func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	switch msg := msg.(type) {
-	case tea.KeyMsg:
-		if key.Matches(msg, pkg.Keys.Help) {
-			m.help.ShowAll = !m.help.ShowAll
+	case clearStatusMsg:
+		if msg.statusId == m.statusId {
+			m.status = ""
 		}
 	}
 
@@ -55,7 +94,41 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 }
 
 func (m *Model) View(ctx screencontext.ScreenContext) string {
-	return helpStyle.Copy().Width(ctx.ScreenWidth).Render(m.help.View(pkg.Keys))
+	if m.status != "" {
+		style := statusStyle
+		if m.statusIsError {
+			style = statusErrorStyle
+		}
+		return helpStyle.Copy().Width(ctx.ScreenWidth).Render(style.Copy().MaxWidth(ctx.ScreenWidth).Render(m.status))
+	}
+
+	// The full help can be wider than the screen, cut it rather than wrap it.
+	helpView := lipgloss.NewStyle().MaxWidth(ctx.ScreenWidth).Render(m.help.View(keyMap{KeyMap: ctx.Keys, actions: m.actions}))
+	return helpStyle.Copy().Width(ctx.ScreenWidth).Render(helpView)
+}
+
+// ToggleShowAll switches between the short help and the help of every key.
+func (m *Model) ToggleShowAll() {
+	m.help.ShowAll = !m.help.ShowAll
+}
+
+// SetStatus shows text in place of the help for a few seconds. The returned
+// command clears it unless another status replaced it in the meantime.
+func (m *Model) SetStatus(text string, isError bool) tea.Cmd {
+	m.statusId++
+	m.status = text
+	m.statusIsError = isError
+
+	statusId := m.statusId
+	return tea.Tick(statusDuration, func(time.Time) tea.Msg {
+		return clearStatusMsg{statusId: statusId}
+	})
+}
+
+// SetActions sets the keys of the current section's actions listed in the
+// help.
+func (m *Model) SetActions(actions []key.Binding) {
+	m.actions = actions
 }
 
 func (m *Model) SetWidth(width int) {
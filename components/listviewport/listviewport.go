@@ -18,6 +18,10 @@ var (
 			PaddingTop(1).
 			Bold(true).
 			Foreground(lipgloss.AdaptiveColor{Light: blue.Light, Dark: "#3498db"})
+
+	pagerStatusStyle = lipgloss.NewStyle().
+				Faint(true).
+				Bold(false)
 )
 
 type Model struct {
@@ -29,6 +33,11 @@ type Model struct {
 	NumItems       int
 	TabName        string
 	ItemTypeLabel  string
+	// IsFiltered is set while only some items are shown. The pager then shows
+	// the tab name even without items, Status tells how many match.
+	IsFiltered bool
+	// Status is shown after the pager, e.g. to flag outdated rows.
+	Status string
 }
 
 func NewModel(dimensions constants.Dimensions, itemTypeLabel string, numItems, listItemHeight int, tabName string) Model {
@@ -50,7 +59,7 @@ func NewModel(dimensions constants.Dimensions, itemTypeLabel string, numItems, l
 
 func (m *Model) SetNumItems(numItems int) {
 	m.NumItems = numItems
-	m.bottomBoundId = pkg.Min(m.NumItems-1, m.getNumPrsPerPage()-1)
+	m.bottomBoundId = pkg.Min(m.NumItems-1, m.topBoundId+m.getNumPrsPerPage()-1)
 }
 
 func (m *Model) SyncViewPort(content string) {
@@ -63,12 +72,37 @@ func (m *Model) getNumPrsPerPage() int {
 
 func (m *Model) ResetCurrItem() {
 	m.currId = 0
+	m.topBoundId = 0
+	m.bottomBoundId = pkg.Min(m.NumItems-1, m.getNumPrsPerPage()-1)
+	m.viewport.GotoTop()
+}
+
+// SetCurrItem moves the cursor to the item at id, scrolling it into view.
+func (m *Model) SetCurrItem(id int) {
+	if m.NumItems == 0 {
+		m.ResetCurrItem()
+		return
+	}
+
+	m.currId = pkg.Max(pkg.Min(id, m.NumItems-1), 0)
+	numPerPage := pkg.Max(m.getNumPrsPerPage(), 1)
+	if m.currId < m.topBoundId || m.currId > m.topBoundId+numPerPage-1 {
+		m.topBoundId = pkg.Max(m.currId-numPerPage+1, 0)
+	}
+	m.bottomBoundId = pkg.Min(m.NumItems-1, m.topBoundId+numPerPage-1)
+	m.viewport.YOffset = m.topBoundId * m.ListItemHeight
 }
 
 func (m *Model) GetCurrItem() int {
 	return m.currId
 }
 
+// IsNearBottom reports whether the cursor is within threshold items of the
+// last one.
+func (m *Model) IsNearBottom(threshold int) bool {
+	return m.NumItems > 0 && m.currId >= m.NumItems-1-threshold
+}
+
 func (m *Model) NextItem() int {
 	atBottomOfViewport := m.currId >= m.bottomBoundId
 	if atBottomOfViewport {
@@ -98,6 +132,7 @@ func (m *Model) PrevItem() int {
 func (m *Model) SetDimensions(dimensions constants.Dimensions) {
 	m.viewport.Height = dimensions.Height - pagerHeight
 	m.viewport.Width = dimensions.Width
+	m.bottomBoundId = pkg.Min(m.NumItems-1, m.topBoundId+m.getNumPrsPerPage()-1)
 }
 
 func (m *Model) View() string {
@@ -110,6 +145,12 @@ func (m *Model) View() string {
 			m.NumItems,
 		)
 	}
+	if m.IsFiltered && pagerContent == "" {
+		pagerContent = m.TabName
+	}
+	if m.Status != "" {
+		pagerContent += pagerStatusStyle.Render(" · " + m.Status)
+	}
 	viewport := m.viewport.View()
 	pager := pagerStyle.Copy().Render(pagerContent)
 	return lipgloss.NewStyle().
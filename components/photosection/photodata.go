@@ -0,0 +1,45 @@
+package photosection
+
+import (
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mehmetcantas/medium-cli/components/table"
+	"github.com/mehmetcantas/medium-cli/pkg"
+)
+
+type Photo struct {
+	Data  PhotoModel
+	Width int
+}
+
+type PhotoModel struct {
+	AlbumId      int    `json:"albumId"`
+	Id           int    `json:"id"`
+	Title        string `json:"title"`
+	Url          string `json:"url"`
+	ThumbnailUrl string `json:"thumbnailUrl"`
+}
+
+func (p *Photo) ToTableRow() table.Row {
+	return table.Row{
+		p.renderId(),
+		p.renderTitle(),
+		p.renderAlbumId(),
+		p.renderUrl(),
+	}
+}
+
+func (p *Photo) renderId() string {
+	return lipgloss.NewStyle().Render(pkg.CastIntToStr(p.Data.Id))
+}
+
+func (p *Photo) renderTitle() string {
+	return lipgloss.NewStyle().Render(p.Data.Title)
+}
+
+func (p *Photo) renderAlbumId() string {
+	return lipgloss.NewStyle().Render(pkg.CastIntToStr(p.Data.AlbumId))
+}
+
+func (p *Photo) renderUrl() string {
+	return lipgloss.NewStyle().Faint(true).Render(p.Data.Url)
+}
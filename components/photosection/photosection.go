@@ -0,0 +1,58 @@
+package photosection
+
+import (
+	"github.com/mehmetcantas/medium-cli/components/placeholdersection"
+	"github.com/mehmetcantas/medium-cli/components/section"
+	"github.com/mehmetcantas/medium-cli/components/table"
+	"github.com/mehmetcantas/medium-cli/config"
+	"github.com/mehmetcantas/medium-cli/ui/screencontext"
+)
+
+const SectionType = "photos"
+
+var (
+	idCellWidth  = 10
+	urlCellWidth = 40
+	growCell     = true
+)
+
+var kind = section.Kind{
+	Type:       SectionType,
+	ItemLabel:  "Photo",
+	EmptyState: "No photos found",
+}
+
+type source struct {
+	placeholdersection.Fetcher
+}
+
+func NewModel(id int, ctx *screencontext.ScreenContext, config config.SectionConfig, view config.ViewType) section.Model {
+	fetcher := placeholdersection.NewFetcher(ctx, config, func() interface{} { return &[]PhotoModel{} })
+	return section.NewModel(id, ctx, config, view, kind, source{fetcher})
+}
+
+func (s source) Columns() []table.Column {
+	return []table.Column{
+		{
+			Title: "ID",
+			Width: &idCellWidth,
+		},
+		{
+			Title: "Title",
+			Grow:  &growCell,
+		},
+		{
+			Title: "Album ID",
+			Width: &idCellWidth,
+		},
+		{
+			Title: "URL",
+			Width: &urlCellWidth,
+		},
+	}
+}
+
+func (s source) BuildRow(record interface{}, width int) table.Row {
+	photoModel := Photo{Data: record.(PhotoModel), Width: width}
+	return photoModel.ToTableRow()
+}
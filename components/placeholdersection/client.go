@@ -1,22 +1,69 @@
 package placeholdersection
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"log"
 	"net"
 	"net/http"
+	"sort"
+	"strings"
 	"sync"
 	"time"
+
+	"github.com/mehmetcantas/medium-cli/config"
+	"github.com/mehmetcantas/medium-cli/pkg"
+	"github.com/mehmetcantas/medium-cli/pkg/httpcache"
 )
 
 type PlaceholderClient struct {
 	client  *http.Client
 	baseURL string
+	// header is sent with every request to the source.
+	header http.Header
+	retry  config.RetryConfig
+	cache  *httpcache.Cache
+	// cacheScope keeps the cached responses of this source and credentials
+	// apart from those of other sources requesting the same URLs.
+	cacheScope string
+}
+
+// Request describes what Fetch should return and how fresh it must be.
+type Request struct {
+	Query string
+	// TTL is how long a cached response is used without asking the server.
+	TTL time.Duration
+	// Force skips the TTL for a refresh asked for by the user or the refresh
+	// interval. A cached copy is still revalidated with a conditional request.
+	Force bool
+	// Offline serves the request from the cache only.
+	Offline bool
+	Page    Page
+	// Decode parses a response body into the result, json.Unmarshal when nil.
+	Decode func(data []byte, result interface{}) error
+}
+
+// Response describes where the data returned by Fetch came from.
+type Response struct {
+	FetchedAt time.Time
+	// Stale is set for cached data that is being revalidated.
+	Stale bool
+	// Offline is set for cached data returned without trying the network.
+	Offline bool
+	// Total is the number of items of the whole resource, or -1 when the
+	// server did not tell.
+	Total int
+	// Next is the page following the returned one, nil for the last page.
+	Next *Page
 }
 
-func NewPlaceholderClient(baseURL string) *PlaceholderClient {
+func NewPlaceholderClient(source config.Source, retry config.RetryConfig, cache *httpcache.Cache) *PlaceholderClient {
 	var client *http.Client
 
 	clientOnce := sync.Once{}
@@ -36,31 +83,195 @@ func NewPlaceholderClient(baseURL string) *PlaceholderClient {
 	})
 
 	return &PlaceholderClient{
-		client:  client,
-		baseURL: baseURL,
+		client:     client,
+		baseURL:    source.BaseURL,
+		header:     source.Header,
+		retry:      retry,
+		cache:      cache,
+		cacheScope: newCacheScope(source),
+	}
+}
+
+// newCacheScope returns the source name followed by a hash of the headers sent
+// to it, so changing credentials does not serve the responses of the old ones.
+func newCacheScope(source config.Source) string {
+	keys := make([]string, 0, len(source.Header))
+	for key := range source.Header {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	hash := sha256.New()
+	for _, key := range keys {
+		for _, value := range source.Header[key] {
+			fmt.Fprintf(hash, "%s: %s\n", key, value)
+		}
+	}
+	return source.Name + ":" + hex.EncodeToString(hash.Sum(nil))
+}
+
+// Get returns the placeholders of query. Returned errors are one of
+// NetworkError, TimeoutError, StatusError or DecodeError.
+func (p *PlaceholderClient) Get(query string) ([]PlaceholderModel, error) {
+	newResult := func() interface{} { return &[]PlaceholderModel{} }
+	result, _, err := p.Fetch(context.Background(), Request{Query: query}, newResult, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return *result.(*[]PlaceholderModel), nil
+}
+
+// Fetch decodes the response of the request's query into a value created by
+// newResult and returns it. A cached copy younger than the request's TTL is
+// returned without asking the server, unless the request is forced; an older
+// one is handed to onStale first and then revalidated with a conditional
+// request. Offline requests are served
+// from the cache only and fail with ErrNotCached when there is no copy.
+func (p *PlaceholderClient) Fetch(
+	ctx context.Context,
+	request Request,
+	newResult func() interface{},
+	onStale func(result interface{}, resp Response),
+) (interface{}, Response, error) {
+	url := p.pageURL(request.Query, request.Page)
+
+	entry, ok := p.cache.Get(p.cacheScope, url)
+	if ok {
+		cached := newResult()
+		if err := request.decode(entry.Body, cached); err != nil {
+			entry, ok = httpcache.Entry{}, false
+		} else if request.Offline {
+			resp := newResponse(request.Page, cached, entry)
+			resp.Offline = true
+			return cached, resp, nil
+		} else if !request.Force && request.TTL > 0 && time.Since(entry.StoredAt) < request.TTL {
+			return cached, newResponse(request.Page, cached, entry), nil
+		} else if onStale != nil {
+			resp := newResponse(request.Page, cached, entry)
+			resp.Stale = true
+			onStale(cached, resp)
+		}
+	}
+	if request.Offline {
+		return nil, Response{Offline: true, Total: -1}, ErrNotCached
+	}
+
+	entry, err := p.getWithRetry(ctx, url, entry)
+	if err != nil {
+		return nil, Response{Total: -1}, err
+	}
+
+	result := newResult()
+	if err := request.decode(entry.Body, result); err != nil {
+		return nil, Response{Total: -1}, newDecodeError(url, err)
+	}
+
+	return result, newResponse(request.Page, result, entry), nil
+}
+
+func (r Request) decode(data []byte, result interface{}) error {
+	if r.Decode == nil {
+		return json.Unmarshal(data, result)
 	}
+	return r.Decode(data, result)
+}
+
+func newResponse(page Page, result interface{}, entry httpcache.Entry) Response {
+	next, total := nextPage(page, entry.URL, countItems(result), entry.Header)
+	return Response{FetchedAt: entry.StoredAt, Total: total, Next: next}
 }
 
-func (p *PlaceholderClient) Get(query string) []PlaceholderModel {
+func (p *PlaceholderClient) url(query string) string {
+	return p.baseURL + "/" + query
+}
+
+// getWithRetry performs the request until it succeeds, fails with an error
+// that is not worth retrying, or runs out of attempts. Each retry is reported
+// through pkg.NotifyRetry.
+func (p *PlaceholderClient) getWithRetry(ctx context.Context, url string, cached httpcache.Entry) (httpcache.Entry, error) {
+	maxAttempts := pkg.Max(p.retry.MaxAttempts, 1)
+	for attempt := 1; ; attempt++ {
+		entry, err := p.get(ctx, url, cached)
+		if err == nil || attempt >= maxAttempts || !isRetryable(p.retry, err) {
+			return entry, err
+		}
+
+		var retryAfter time.Duration
+		var statusErr *StatusError
+		if errors.As(err, &statusErr) {
+			retryAfter = statusErr.RetryAfter
+		}
+
+		log.Printf("Request to %s failed, retrying (%d/%d): %v\n", url, attempt+1, maxAttempts, err)
+		pkg.NotifyRetry(ctx, attempt+1, maxAttempts, err)
+		if err := sleepContext(ctx, backoffDelay(p.retry, attempt, retryAfter)); err != nil {
+			return httpcache.Entry{}, err
+		}
+	}
+}
+
+// get performs a single request. When cached holds a previous response the
+// request is conditional, and a 304 answer returns the cached entry.
+func (p *PlaceholderClient) get(ctx context.Context, url string, cached httpcache.Entry) (httpcache.Entry, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return httpcache.Entry{}, &NetworkError{URL: url, Err: err}
+	}
+	// Links may point anywhere, credentials only go to the source itself.
+	if strings.HasPrefix(url, p.baseURL+"/") {
+		for key, values := range p.header {
+			req.Header[key] = values
+		}
+	}
+	if cached.ETag != "" {
+		req.Header.Set("If-None-Match", cached.ETag)
+	}
+	if cached.LastModified != "" {
+		req.Header.Set("If-Modified-Since", cached.LastModified)
+	}
 
-	req, _ := http.NewRequest("GET", p.baseURL+"/"+query, nil)
 	resp, err := p.client.Do(req)
 	if err != nil {
-		fmt.Println(err)
+		return httpcache.Entry{}, newRequestError(url, err)
 	}
 	defer resp.Body.Close()
+
+	now := time.Now()
+	if resp.StatusCode == http.StatusNotModified && cached.URL != "" {
+		cached.StoredAt = now
+		p.storeInCache(cached)
+		return cached, nil
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, bodyExcerptLength*4))
+		return httpcache.Entry{}, newStatusError(url, resp, body)
+	}
+
 	respString, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		log.Printf("An error happened while reading response body : %v\n", err)
+		return httpcache.Entry{}, newRequestError(url, err)
 	}
-	var result []PlaceholderModel
-	err = json.Unmarshal(respString, &result)
 
-	if err != nil {
-		log.Printf("An error happened while parsing response body : %v\n", err)
+	entry := httpcache.Entry{
+		Scope:        p.cacheScope,
+		URL:          url,
+		Body:         respString,
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+		StoredAt:     now,
+		Header:       keepPageHeaders(resp.Header),
 	}
+	p.storeInCache(entry)
 
-	return result
+	return entry, nil
+}
+
+func (p *PlaceholderClient) storeInCache(entry httpcache.Entry) {
+	if err := p.cache.Put(entry); err != nil {
+		log.Printf("Could not cache response of %s: %v\n", entry.URL, err)
+	}
 }
 
 func (p *PlaceholderClient) GetBaseURL() string {
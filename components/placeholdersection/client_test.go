@@ -0,0 +1,150 @@
+package placeholdersection
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/mehmetcantas/medium-cli/config"
+	"github.com/mehmetcantas/medium-cli/pkg/httpcache"
+)
+
+func TestFetchTTL(t *testing.T) {
+	tests := []struct {
+		name            string
+		force           bool
+		wantRequests    int
+		wantConditional bool
+	}{
+		{name: "within TTL", force: false, wantRequests: 0},
+		{name: "forced", force: true, wantRequests: 1, wantConditional: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var requests int
+			var conditional bool
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				if r.Header.Get("If-None-Match") == `"v1"` {
+					conditional = true
+					w.WriteHeader(http.StatusNotModified)
+					return
+				}
+				w.Header().Set("ETag", `"v1"`)
+				w.Write([]byte(`[{"id": 1, "title": "first"}]`))
+			}))
+			defer server.Close()
+
+			cache, err := httpcache.New(t.TempDir(), 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			client := NewPlaceholderClient(config.Source{BaseURL: server.URL}, testRetry, cache)
+			newResult := func() interface{} { return &[]PlaceholderModel{} }
+			request := Request{Query: "todos", TTL: time.Hour}
+			if _, _, err := client.Fetch(context.Background(), request, newResult, nil); err != nil {
+				t.Fatalf("first Fetch() error = %v", err)
+			}
+			requests = 0
+
+			request.Force = test.force
+			result, _, err := client.Fetch(context.Background(), request, newResult, nil)
+			if err != nil {
+				t.Fatalf("Fetch() error = %v", err)
+			}
+			if placeholders := *result.(*[]PlaceholderModel); len(placeholders) != 1 {
+				t.Errorf("Fetch() = %+v, want the cached item", placeholders)
+			}
+			if requests != test.wantRequests {
+				t.Errorf("got %d requests, want %d", requests, test.wantRequests)
+			}
+			if conditional != test.wantConditional {
+				t.Errorf("conditional request = %v, want %v", conditional, test.wantConditional)
+			}
+		})
+	}
+}
+
+func TestGet(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		want    []PlaceholderModel
+		wantErr interface{}
+	}{
+		{
+			name:   "placeholders",
+			status: http.StatusOK,
+			body:   `[{"userId": 1, "id": 1, "title": "first"}, {"userId": 1, "id": 2, "title": "second"}]`,
+			want:   []PlaceholderModel{{UserId: 1, Id: 1, Title: "first"}, {UserId: 1, Id: 2, Title: "second"}},
+		},
+		{name: "status error", status: http.StatusNotFound, body: `{}`, wantErr: new(*StatusError)},
+		{name: "decode error", status: http.StatusOK, body: `[{"id": "one"}]`, wantErr: new(*DecodeError)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(test.status)
+				w.Write([]byte(test.body))
+			}))
+			defer server.Close()
+
+			client := NewPlaceholderClient(config.Source{BaseURL: server.URL}, testRetry, nil)
+			got, err := client.Get("todos")
+			if test.wantErr != nil {
+				if err == nil || !errors.As(err, test.wantErr) {
+					t.Fatalf("Get() error = %v, want a %s", err, test.name)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Get() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("Get() = %+v, want %+v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestFetchKeepsCredentialsApart(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"id": 1, "title": "` + r.Header.Get("Authorization") + `"}]`))
+	}))
+	defer server.Close()
+
+	cache, err := httpcache.New(t.TempDir(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sources := []config.Source{
+		{Name: "alice", BaseURL: server.URL, Header: http.Header{"Authorization": {"Bearer alice"}}},
+		{Name: "bob", BaseURL: server.URL, Header: http.Header{"Authorization": {"Bearer bob"}}},
+		{Name: "alice", BaseURL: server.URL, Header: http.Header{"Authorization": {"Bearer rotated"}}},
+	}
+	for _, source := range sources {
+		client := NewPlaceholderClient(source, testRetry, cache)
+		newResult := func() interface{} { return &[]PlaceholderModel{} }
+		request := Request{Query: "todos", TTL: time.Hour}
+		if _, _, err := client.Fetch(context.Background(), request, newResult, nil); err != nil {
+			t.Fatalf("first Fetch() error = %v", err)
+		}
+
+		// Within the TTL the second fetch is served from the cache.
+		result, _, err := client.Fetch(context.Background(), request, newResult, nil)
+		if err != nil {
+			t.Fatalf("Fetch() error = %v", err)
+		}
+		want := source.Header.Get("Authorization")
+		if placeholders := *result.(*[]PlaceholderModel); len(placeholders) != 1 || placeholders[0].Title != want {
+			t.Errorf("source %s with %q got %+v from the cache", source.Name, want, placeholders)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package placeholdersection
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const bodyExcerptLength = 200
+
+// ErrNotCached is returned by offline requests that have no cached response.
+var ErrNotCached = errors.New("not available offline, it was never fetched while online")
+
+// NetworkError is returned when the server could not be reached at all.
+type NetworkError struct {
+	URL string
+	Err error
+}
+
+func (e *NetworkError) Error() string {
+	return fmt.Sprintf("could not reach %s: %v", e.URL, e.Err)
+}
+
+func (e *NetworkError) Unwrap() error {
+	return e.Err
+}
+
+// TimeoutError is returned when the request did not complete in time.
+type TimeoutError struct {
+	URL string
+	Err error
+}
+
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("request to %s timed out", e.URL)
+}
+
+func (e *TimeoutError) Unwrap() error {
+	return e.Err
+}
+
+// StatusError is returned for non-2xx responses and keeps the beginning of the
+// response body, which usually explains what went wrong.
+type StatusError struct {
+	URL        string
+	StatusCode int
+	Status     string
+	Body       string
+	RetryAfter time.Duration
+}
+
+func (e *StatusError) Error() string {
+	if e.Body == "" {
+		return fmt.Sprintf("%s returned %s", e.URL, e.Status)
+	}
+	return fmt.Sprintf("%s returned %s: %s", e.URL, e.Status, e.Body)
+}
+
+// DecodeError is returned when the response body is not the JSON we expect.
+// Field is empty when the body is not valid JSON at all.
+type DecodeError struct {
+	URL   string
+	Field string
+	Err   error
+}
+
+func (e *DecodeError) Error() string {
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(e.Err, &typeErr) {
+		return fmt.Sprintf(
+			"could not decode response from %s: field %q should be %s but got %s",
+			e.URL,
+			e.Field,
+			typeErr.Type,
+			typeErr.Value,
+		)
+	}
+	return fmt.Sprintf("could not decode response from %s: %v", e.URL, e.Err)
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// IsConnectionError reports whether err means the server could not be reached,
+// as opposed to the server answering with an error.
+func IsConnectionError(err error) bool {
+	var networkErr *NetworkError
+	var timeoutErr *TimeoutError
+	return errors.As(err, &networkErr) || errors.As(err, &timeoutErr)
+}
+
+func newRequestError(url string, err error) error {
+	if errors.Is(err, context.Canceled) {
+		return context.Canceled
+	}
+
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		return &TimeoutError{URL: url, Err: err}
+	}
+	return &NetworkError{URL: url, Err: err}
+}
+
+func newStatusError(url string, resp *http.Response, body []byte) error {
+	excerpt := strings.Join(strings.Fields(string(body)), " ")
+	if len(excerpt) > bodyExcerptLength {
+		excerpt = excerpt[:bodyExcerptLength] + "…"
+	}
+	return &StatusError{
+		URL:        url,
+		StatusCode: resp.StatusCode,
+		Status:     resp.Status,
+		Body:       excerpt,
+		RetryAfter: parseRetryAfter(resp.Header),
+	}
+}
+
+func newDecodeError(url string, err error) error {
+	decodeErr := &DecodeError{URL: url, Err: err}
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		decodeErr.Field = typeErr.Field
+	}
+	return decodeErr
+}
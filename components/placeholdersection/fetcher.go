@@ -0,0 +1,91 @@
+package placeholdersection
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/mehmetcantas/medium-cli/components/section"
+	"github.com/mehmetcantas/medium-cli/config"
+	"github.com/mehmetcantas/medium-cli/pkg/filter"
+	"github.com/mehmetcantas/medium-cli/ui/screencontext"
+)
+
+// Fetcher fetches the records of a section with a PlaceholderClient, page by
+// page. It implements the Fetch method of section.Source for the section types
+// reading an HTTP resource.
+type Fetcher struct {
+	Client *PlaceholderClient
+	Config config.SectionConfig
+	// NewResult returns a pointer to an empty slice of records to decode a
+	// response into.
+	NewResult func() interface{}
+	// Decode parses a response body into the result, json.Unmarshal when nil.
+	Decode func(data []byte, result interface{}) error
+	// Unpaged fetches the whole resource at once, whatever the page size of
+	// the section.
+	Unpaged bool
+}
+
+// NewFetcher returns a fetcher reading the section's resource from its source.
+func NewFetcher(ctx *screencontext.ScreenContext, sectionConfig config.SectionConfig, newResult func() interface{}) Fetcher {
+	return Fetcher{
+		Client:    NewPlaceholderClient(ctx.GetSource(sectionConfig.GetSource()), ctx.Config.Retry, ctx.Cache),
+		Config:    sectionConfig,
+		NewResult: newResult,
+	}
+}
+
+func (f Fetcher) Fetch(ctx context.Context, request section.FetchRequest, onStale func(section.Page)) (section.Page, error) {
+	page := Page{}
+	if next, ok := request.Page.(Page); ok {
+		page = next
+	} else if !f.Unpaged {
+		page = NewFirstPage(f.Config)
+	}
+
+	clientRequest := Request{
+		Query:   f.Config.GetPath(),
+		TTL:     f.Config.TTL,
+		Force:   request.Force,
+		Offline: request.Offline,
+		Page:    page,
+		Decode:  f.Decode,
+	}
+	var onStaleResult func(result interface{}, resp Response)
+	if onStale != nil {
+		onStaleResult = func(result interface{}, resp Response) {
+			onStale(newSectionPage(result, resp, request.Filter))
+		}
+	}
+
+	result, resp, err := f.Client.Fetch(ctx, clientRequest, f.NewResult, onStaleResult)
+	return newSectionPage(result, resp, request.Filter), err
+}
+
+// newSectionPage returns the records of the slice result points to matching
+// query, as a page of a section.
+func newSectionPage(result interface{}, resp Response, query *filter.Query) section.Page {
+	page := section.Page{
+		Total:     resp.Total,
+		FetchedAt: resp.FetchedAt,
+		Stale:     resp.Stale,
+		Offline:   resp.Offline,
+	}
+	if resp.Next != nil {
+		page.Next = *resp.Next
+	}
+
+	value := reflect.ValueOf(result)
+	for value.Kind() == reflect.Ptr {
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Slice {
+		return page
+	}
+	for i := 0; i < value.Len(); i++ {
+		if record := value.Index(i).Interface(); query.Match(record) {
+			page.Records = append(page.Records, record)
+		}
+	}
+	return page
+}
@@ -0,0 +1,144 @@
+package placeholdersection
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/mehmetcantas/medium-cli/config"
+)
+
+const (
+	totalCountHeader = "X-Total-Count"
+	linkHeader       = "Link"
+)
+
+// pageHeaders are the response headers kept in the cache to page through it.
+var pageHeaders = []string{totalCountHeader, linkHeader}
+
+var nextLinkRegexp = regexp.MustCompile(`<([^>]*)>\s*;[^,]*rel="?next"?`)
+
+// Page selects a slice of a paginated resource. Its zero value asks for the
+// whole resource.
+type Page struct {
+	Pagination config.PaginationType
+	// Size is the number of items per page, zero when the resource is not
+	// paginated. Link pagination ignores it, the server picks the page size.
+	Size   int
+	Offset int
+	// URL is the address of the page for link pagination, empty for the
+	// first page.
+	URL string
+}
+
+// NewFirstPage returns the first page of a section's resource.
+func NewFirstPage(sectionConfig config.SectionConfig) Page {
+	return Page{
+		Pagination: sectionConfig.GetPagination(),
+		Size:       sectionConfig.GetPageSize(),
+	}
+}
+
+func (p *PlaceholderClient) pageURL(query string, page Page) string {
+	if page.URL != "" {
+		return page.URL
+	}
+
+	address := p.url(query)
+	if page.Size <= 0 {
+		return address
+	}
+
+	separator := "?"
+	if strings.Contains(address, "?") {
+		separator = "&"
+	}
+
+	switch page.Pagination {
+	case config.LinkPagination:
+		// The server picks the page size and links to the following pages.
+		return address
+	case config.RangePagination:
+		return fmt.Sprintf("%s%s_start=%d&_end=%d", address, separator, page.Offset, page.Offset+page.Size)
+	default:
+		return fmt.Sprintf("%s%s_page=%d&_limit=%d", address, separator, page.Offset/page.Size+1, page.Size)
+	}
+}
+
+// nextPage returns the page following page, which was fetched from pageURL and
+// returned numItems items along with header, or nil when it was the last one.
+// total is the number of items announced by the server, or -1 when it did not
+// tell.
+func nextPage(page Page, pageURL string, numItems int, header map[string]string) (next *Page, total int) {
+	total = -1
+	if count, err := strconv.Atoi(header[totalCountHeader]); err == nil {
+		total = count
+	}
+
+	if page.Pagination == config.LinkPagination {
+		match := nextLinkRegexp.FindStringSubmatch(header[linkHeader])
+		if match == nil {
+			return nil, total
+		}
+		nextURL, err := resolveLink(pageURL, match[1])
+		if err != nil {
+			log.Printf("Ignoring the next link of %s: %v\n", pageURL, err)
+			return nil, total
+		}
+		return &Page{Pagination: page.Pagination, Size: page.Size, Offset: page.Offset + numItems, URL: nextURL}, total
+	}
+
+	if page.Size <= 0 {
+		return nil, total
+	}
+
+	loaded := page.Offset + numItems
+	if numItems < page.Size || (total >= 0 && loaded >= total) {
+		return nil, total
+	}
+	return &Page{Pagination: page.Pagination, Size: page.Size, Offset: loaded}, total
+}
+
+// resolveLink returns the absolute URL of link, which may be relative to the
+// URL of the page it was found on.
+func resolveLink(pageURL string, link string) (string, error) {
+	base, err := url.Parse(pageURL)
+	if err != nil {
+		return "", err
+	}
+	ref, err := url.Parse(link)
+	if err != nil {
+		return "", err
+	}
+	return base.ResolveReference(ref).String(), nil
+}
+
+func keepPageHeaders(header http.Header) map[string]string {
+	kept := map[string]string{}
+	for _, name := range pageHeaders {
+		if value := header.Get(name); value != "" {
+			kept[name] = value
+		}
+	}
+	if len(kept) == 0 {
+		return nil
+	}
+	return kept
+}
+
+// countItems returns the length of the slice result points to.
+func countItems(result interface{}) int {
+	value := reflect.ValueOf(result)
+	for value.Kind() == reflect.Ptr {
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Slice {
+		return 0
+	}
+	return value.Len()
+}
@@ -0,0 +1,139 @@
+package placeholdersection
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/mehmetcantas/medium-cli/components/section"
+	"github.com/mehmetcantas/medium-cli/config"
+)
+
+func TestPageURL(t *testing.T) {
+	client := NewPlaceholderClient(config.Source{BaseURL: "https://api.example.com"}, config.RetryConfig{}, nil)
+	tests := []struct {
+		name  string
+		query string
+		page  Page
+		want  string
+	}{
+		{name: "unpaged", query: "posts", page: Page{}, want: "https://api.example.com/posts"},
+		{
+			name:  "page",
+			query: "posts",
+			page:  Page{Pagination: config.PagePagination, Size: 10, Offset: 20},
+			want:  "https://api.example.com/posts?_page=3&_limit=10",
+		},
+		{
+			name:  "range",
+			query: "posts?userId=1",
+			page:  Page{Pagination: config.RangePagination, Size: 10, Offset: 20},
+			want:  "https://api.example.com/posts?userId=1&_start=20&_end=30",
+		},
+		{
+			name:  "first link page",
+			query: "posts",
+			page:  Page{Pagination: config.LinkPagination, Size: 10},
+			want:  "https://api.example.com/posts",
+		},
+		{
+			name:  "next link page",
+			query: "posts",
+			page:  Page{Pagination: config.LinkPagination, Size: 10, Offset: 10, URL: "https://api.example.com/posts?cursor=abc"},
+			want:  "https://api.example.com/posts?cursor=abc",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := client.pageURL(test.query, test.page); got != test.want {
+				t.Errorf("pageURL() = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestNextLinkPage(t *testing.T) {
+	tests := []struct {
+		name    string
+		link    string
+		wantURL string
+	}{
+		{name: "no link", link: "", wantURL: ""},
+		{name: "last page", link: `<https://api.example.com/posts?cursor=a>; rel="prev"`, wantURL: ""},
+		{
+			name:    "absolute",
+			link:    `<https://api.example.com/posts?cursor=b>; rel="next"`,
+			wantURL: "https://api.example.com/posts?cursor=b",
+		},
+		{
+			name:    "relative to the path",
+			link:    `</v2/posts?cursor=b>; rel="next"`,
+			wantURL: "https://api.example.com/v2/posts?cursor=b",
+		},
+		{
+			name:    "relative query",
+			link:    `<?cursor=b>; rel="prev", <?cursor=c>; rel=next`,
+			wantURL: "https://api.example.com/v1/posts?cursor=c",
+		},
+	}
+
+	for _, test := range tests {
+		// The server picks the page size, with or without a limit.
+		for _, size := range []int{0, 10} {
+			t.Run(fmt.Sprintf("%s with size %d", test.name, size), func(t *testing.T) {
+				page := Page{Pagination: config.LinkPagination, Size: size}
+				next, _ := nextPage(page, "https://api.example.com/v1/posts", 10, map[string]string{linkHeader: test.link})
+				if test.wantURL == "" {
+					if next != nil {
+						t.Errorf("nextPage() = %+v, want nil", next)
+					}
+					return
+				}
+				if next == nil || next.URL != test.wantURL || next.Offset != 10 {
+					t.Errorf("nextPage() = %+v, want page at offset 10 with URL %q", next, test.wantURL)
+				}
+			})
+		}
+	}
+}
+
+func TestFetchLinkPagesWithoutLimit(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("cursor") == "" {
+			w.Header().Set(linkHeader, `<`+server.URL+`/posts?cursor=2>; rel="next"`)
+			w.Write([]byte(`[{"id": 1}, {"id": 2}]`))
+			return
+		}
+		w.Write([]byte(`[{"id": 3}]`))
+	}))
+	defer server.Close()
+
+	fetcher := Fetcher{
+		Client:    NewPlaceholderClient(config.Source{BaseURL: server.URL}, testRetry, nil),
+		Config:    config.SectionConfig{Title: "Posts", Filters: "posts", Pagination: config.LinkPagination},
+		NewResult: func() interface{} { return &[]PlaceholderModel{} },
+	}
+	var ids []int
+	request := section.FetchRequest{}
+	for pages := 0; pages < 3; pages++ {
+		page, err := fetcher.Fetch(context.Background(), request, nil)
+		if err != nil {
+			t.Fatalf("Fetch() error = %v", err)
+		}
+		for _, record := range page.Records {
+			ids = append(ids, record.(PlaceholderModel).Id)
+		}
+		if page.Next == nil {
+			break
+		}
+		request.Page = page.Next
+	}
+	if want := []int{1, 2, 3}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("fetched ids %v, want %v", ids, want)
+	}
+}
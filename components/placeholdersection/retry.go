@@ -0,0 +1,93 @@
+package placeholdersection
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/mehmetcantas/medium-cli/config"
+)
+
+var (
+	jitterMu   sync.Mutex
+	jitterRand = rand.New(rand.NewSource(time.Now().UnixNano()))
+)
+
+func isRetryable(retry config.RetryConfig, err error) bool {
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		for _, status := range retry.RetryableStatuses {
+			if status == statusErr.StatusCode {
+				return true
+			}
+		}
+		return false
+	}
+
+	var networkErr *NetworkError
+	var timeoutErr *TimeoutError
+	return errors.As(err, &networkErr) || errors.As(err, &timeoutErr)
+}
+
+// backoffDelay returns how long to wait after the given failed attempt. The
+// exponential delay is jittered between half and all of its value so clients
+// failing together do not retry together. A Retry-After value from the server
+// takes precedence; both are capped at MaxDelay.
+func backoffDelay(retry config.RetryConfig, attempt int, retryAfter time.Duration) time.Duration {
+	if retryAfter > 0 {
+		if retryAfter > retry.MaxDelay {
+			return retry.MaxDelay
+		}
+		return retryAfter
+	}
+
+	delay := retry.BaseDelay
+	for i := 1; i < attempt && delay < retry.MaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > retry.MaxDelay {
+		delay = retry.MaxDelay
+	}
+	if delay <= 0 {
+		return 0
+	}
+
+	jitterMu.Lock()
+	defer jitterMu.Unlock()
+	return delay/2 + time.Duration(jitterRand.Int63n(int64(delay/2)+1))
+}
+
+// parseRetryAfter reads a Retry-After header given either in seconds or as an
+// HTTP date.
+func parseRetryAfter(header http.Header) time.Duration {
+	value := header.Get("Retry-After")
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		if delay := time.Until(date); delay > 0 {
+			return delay
+		}
+	}
+
+	return 0
+}
+
+func sleepContext(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
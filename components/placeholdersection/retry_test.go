@@ -0,0 +1,243 @@
+package placeholdersection
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mehmetcantas/medium-cli/config"
+	"github.com/mehmetcantas/medium-cli/pkg"
+)
+
+var testRetry = config.RetryConfig{
+	MaxAttempts:       3,
+	BaseDelay:         time.Millisecond,
+	MaxDelay:          10 * time.Millisecond,
+	RetryableStatuses: []int{http.StatusTooManyRequests, http.StatusServiceUnavailable},
+}
+
+// failingServer answers the first failures requests with status, along with
+// header, and the following ones with a JSON array of one item.
+type failingServer struct {
+	*httptest.Server
+	mu       sync.Mutex
+	requests []time.Time
+}
+
+func newFailingServer(t *testing.T, failures int, status int, header http.Header) *failingServer {
+	s := &failingServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.requests = append(s.requests, time.Now())
+		attempt := len(s.requests)
+		s.mu.Unlock()
+
+		if attempt <= failures {
+			for key, values := range header {
+				w.Header()[key] = values
+			}
+			w.WriteHeader(status)
+			return
+		}
+		w.Write([]byte(`[{"id": 1, "title": "first"}]`))
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *failingServer) numRequests() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.requests)
+}
+
+func fetchPlaceholders(ctx context.Context, client *PlaceholderClient) ([]PlaceholderModel, error) {
+	result, _, err := client.Fetch(ctx, Request{Query: "todos"}, func() interface{} { return &[]PlaceholderModel{} }, nil)
+	if err != nil {
+		return nil, err
+	}
+	return *result.(*[]PlaceholderModel), nil
+}
+
+func TestFetchRetriesRetryableFailures(t *testing.T) {
+	tests := []struct {
+		name     string
+		failures int
+		status   int
+	}{
+		{name: "no failure", failures: 0, status: http.StatusServiceUnavailable},
+		{name: "one 503", failures: 1, status: http.StatusServiceUnavailable},
+		{name: "two 503", failures: 2, status: http.StatusServiceUnavailable},
+		{name: "two 429", failures: 2, status: http.StatusTooManyRequests},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := newFailingServer(t, test.failures, test.status, nil)
+			client := NewPlaceholderClient(config.Source{BaseURL: server.URL}, testRetry, nil)
+
+			var notified []int
+			ctx := pkg.WithRetryNotify(context.Background(), func(attempt, maxAttempts int, err error) {
+				notified = append(notified, attempt)
+			})
+			placeholders, err := fetchPlaceholders(ctx, client)
+			if err != nil {
+				t.Fatalf("Fetch() error = %v", err)
+			}
+			if len(placeholders) != 1 || placeholders[0].Title != "first" {
+				t.Errorf("Fetch() = %+v, want the item of the last response", placeholders)
+			}
+			if got := server.numRequests(); got != test.failures+1 {
+				t.Errorf("got %d requests, want %d", got, test.failures+1)
+			}
+			if len(notified) != test.failures {
+				t.Errorf("got %d retry notifications, want %d", len(notified), test.failures)
+			}
+			for i, attempt := range notified {
+				if attempt != i+2 {
+					t.Errorf("retry notification %d is for attempt %d, want %d", i, attempt, i+2)
+				}
+			}
+		})
+	}
+}
+
+func TestFetchGivesUpAfterMaxAttempts(t *testing.T) {
+	server := newFailingServer(t, 10, http.StatusServiceUnavailable, nil)
+	client := NewPlaceholderClient(config.Source{BaseURL: server.URL}, testRetry, nil)
+
+	_, err := fetchPlaceholders(context.Background(), client)
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("Fetch() error = %v, want a 503 StatusError", err)
+	}
+	if got := server.numRequests(); got != testRetry.MaxAttempts {
+		t.Errorf("got %d requests, want %d", got, testRetry.MaxAttempts)
+	}
+}
+
+func TestFetchDoesNotRetryClientErrors(t *testing.T) {
+	for _, status := range []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusNotFound} {
+		t.Run(http.StatusText(status), func(t *testing.T) {
+			server := newFailingServer(t, 10, status, nil)
+			client := NewPlaceholderClient(config.Source{BaseURL: server.URL}, testRetry, nil)
+
+			_, err := fetchPlaceholders(context.Background(), client)
+			var statusErr *StatusError
+			if !errors.As(err, &statusErr) || statusErr.StatusCode != status {
+				t.Fatalf("Fetch() error = %v, want a %d StatusError", err, status)
+			}
+			if got := server.numRequests(); got != 1 {
+				t.Errorf("got %d requests, want 1", got)
+			}
+		})
+	}
+}
+
+func TestFetchHonoursRetryAfter(t *testing.T) {
+	server := newFailingServer(t, 1, http.StatusTooManyRequests, http.Header{"Retry-After": {"1"}})
+	retry := testRetry
+	retry.MaxDelay = 5 * time.Second
+	client := NewPlaceholderClient(config.Source{BaseURL: server.URL}, retry, nil)
+
+	if _, err := fetchPlaceholders(context.Background(), client); err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if got := server.numRequests(); got != 2 {
+		t.Fatalf("got %d requests, want 2", got)
+	}
+	if waited := server.requests[1].Sub(server.requests[0]); waited < 900*time.Millisecond {
+		t.Errorf("retried after %v, want the second of Retry-After", waited)
+	}
+}
+
+func TestFetchStopsWhenCancelled(t *testing.T) {
+	server := newFailingServer(t, 10, http.StatusServiceUnavailable, nil)
+	retry := testRetry
+	retry.BaseDelay = time.Hour
+	retry.MaxDelay = time.Hour
+	client := NewPlaceholderClient(config.Source{BaseURL: server.URL}, retry, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ctx = pkg.WithRetryNotify(ctx, func(attempt, maxAttempts int, err error) {
+		// Cancel while the client waits to retry.
+		cancel()
+	})
+
+	done := make(chan error)
+	go func() {
+		_, err := fetchPlaceholders(ctx, client)
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Fetch() error = %v, want %v", err, context.Canceled)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Fetch() did not return after the context was cancelled")
+	}
+	if got := server.numRequests(); got != 1 {
+		t.Errorf("got %d requests, want 1", got)
+	}
+}
+
+func TestBackoffDelay(t *testing.T) {
+	retry := config.RetryConfig{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+	tests := []struct {
+		name       string
+		attempt    int
+		retryAfter time.Duration
+		min, max   time.Duration
+	}{
+		{name: "first retry", attempt: 1, min: 50 * time.Millisecond, max: 100 * time.Millisecond},
+		{name: "doubles", attempt: 3, min: 200 * time.Millisecond, max: 400 * time.Millisecond},
+		{name: "capped", attempt: 10, min: 500 * time.Millisecond, max: time.Second},
+		{name: "retry after", attempt: 1, retryAfter: 700 * time.Millisecond, min: 700 * time.Millisecond, max: 700 * time.Millisecond},
+		{name: "retry after capped", attempt: 1, retryAfter: time.Minute, min: time.Second, max: time.Second},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for i := 0; i < 20; i++ {
+				delay := backoffDelay(retry, test.attempt, test.retryAfter)
+				if delay < test.min || delay > test.max {
+					t.Fatalf("backoffDelay() = %v, want between %v and %v", delay, test.min, test.max)
+				}
+			}
+		})
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{value: "", want: 0},
+		{value: "3", want: 3 * time.Second},
+		{value: "-1", want: 0},
+		{value: "soon", want: 0},
+		{value: time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat), want: 0},
+	}
+
+	for _, test := range tests {
+		header := http.Header{}
+		if test.value != "" {
+			header.Set("Retry-After", test.value)
+		}
+		if got := parseRetryAfter(header); got != test.want {
+			t.Errorf("parseRetryAfter(%q) = %v, want %v", test.value, got, test.want)
+		}
+	}
+
+	future := time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)
+	if got := parseRetryAfter(http.Header{"Retry-After": {future}}); got < 59*time.Minute || got > time.Hour {
+		t.Errorf("parseRetryAfter(%q) = %v, want about an hour", future, got)
+	}
+}
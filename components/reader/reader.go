@@ -0,0 +1,124 @@
+package reader
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mehmetcantas/medium-cli/pkg"
+	"github.com/mehmetcantas/medium-cli/ui/screencontext"
+)
+
+// maxContentWidth keeps lines short enough to read comfortably on wide
+// terminals.
+const maxContentWidth = 80
+
+var (
+	titleStyle = lipgloss.NewStyle().
+			Bold(true).
+			Padding(0, 2).
+			Foreground(lipgloss.AdaptiveColor{Light: "#242347", Dark: "#E2E1ED"}).
+			BorderBottom(true).
+			BorderStyle(lipgloss.NormalBorder()).
+			BorderForeground(lipgloss.Color("#3498db"))
+
+	contentStyle = lipgloss.NewStyle().PaddingLeft(2)
+
+	footerStyle = lipgloss.NewStyle().
+			Padding(0, 2).
+			Foreground(lipgloss.Color("#3498db")).
+			BorderTop(true).
+			BorderStyle(lipgloss.NormalBorder()).
+			BorderForeground(lipgloss.Color("#3498db"))
+)
+
+// Article is a row that can be read in the reader.
+type Article interface {
+	GetTitle() string
+	GetContentHTML() string
+}
+
+// Model shows an article full screen. The section it was opened from is left
+// untouched, so closing it returns to the same row.
+type Model struct {
+	IsOpen   bool
+	article  Article
+	width    int
+	viewport viewport.Model
+	// backKey is shown in the footer as the way back to the table.
+	backKey string
+}
+
+func NewModel() Model {
+	return Model{
+		IsOpen:   false,
+		viewport: viewport.Model{},
+	}
+}
+
+func (m Model) View() string {
+	if !m.IsOpen || m.article == nil {
+		return ""
+	}
+
+	title := titleStyle.Copy().Width(m.width).MaxWidth(m.width).Render(m.article.GetTitle())
+	progress := fmt.Sprintf("%3d%%", int(m.viewport.ScrollPercent()*100))
+	footer := footerStyle.Copy().Width(m.width).MaxWidth(m.width).Render(
+		fmt.Sprintf("%s · %s back", progress, m.backKey),
+	)
+
+	return lipgloss.JoinVertical(lipgloss.Left, title, m.viewport.View(), footer)
+}
+
+// Open shows article from the top.
+func (m *Model) Open(article Article) {
+	m.article = article
+	m.IsOpen = true
+	m.syncContent()
+	m.viewport.GotoTop()
+}
+
+func (m *Model) Close() {
+	m.IsOpen = false
+	m.article = nil
+}
+
+func (m *Model) LineDown() {
+	m.viewport.LineDown(1)
+}
+
+func (m *Model) LineUp() {
+	m.viewport.LineUp(1)
+}
+
+func (m *Model) PageDown() {
+	m.viewport.HalfViewDown()
+}
+
+func (m *Model) PageUp() {
+	m.viewport.HalfViewUp()
+}
+
+func (m *Model) UpdateScreenContext(ctx *screencontext.ScreenContext) {
+	m.backKey = ctx.Keys.Back.Help().Key
+	height := ctx.ScreenHeight - lipgloss.Height(titleStyle.Render("")) - lipgloss.Height(footerStyle.Render(""))
+	if ctx.ScreenWidth != m.width || height != m.viewport.Height {
+		m.width = ctx.ScreenWidth
+		m.viewport.Width = ctx.ScreenWidth
+		m.viewport.Height = pkg.Max(height, 0)
+		m.syncContent()
+	}
+}
+
+func (m *Model) syncContent() {
+	if m.article == nil || m.width <= 0 {
+		return
+	}
+
+	width := pkg.Min(m.width-contentStyle.GetHorizontalFrameSize(), maxContentWidth)
+	content, err := RenderHTML(m.article.GetContentHTML(), width)
+	if err != nil {
+		content = fmt.Sprintf("Could not render the article: %v", err)
+	}
+	m.viewport.SetContent(contentStyle.Render(content))
+}
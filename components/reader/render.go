@@ -0,0 +1,282 @@
+package reader
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mehmetcantas/medium-cli/pkg"
+	"golang.org/x/net/html"
+)
+
+var (
+	headingStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.AdaptiveColor{Light: "#2980b9", Dark: "#3498db"})
+
+	boldStyle   = lipgloss.NewStyle().Bold(true)
+	italicStyle = lipgloss.NewStyle().Italic(true)
+	linkStyle   = lipgloss.NewStyle().Underline(true).Foreground(lipgloss.AdaptiveColor{Light: "#2980b9", Dark: "#42A0FA"})
+	faintStyle  = lipgloss.NewStyle().Faint(true)
+
+	inlineCodeStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.AdaptiveColor{Light: "#c0392b", Dark: "#e67e22"})
+
+	codeBlockStyle = lipgloss.NewStyle().
+			PaddingLeft(2).
+			Foreground(lipgloss.AdaptiveColor{Light: "#242347", Dark: "#E2E1ED"}).
+			Background(lipgloss.AdaptiveColor{Light: "#ecf0f1", Dark: "#2b2b40"})
+
+	quoteBar = faintStyle.Render("│ ")
+
+	spaceRegexp = regexp.MustCompile(`\s+`)
+)
+
+var blockTags = map[string]bool{
+	"address": true, "article": true, "aside": true, "blockquote": true, "div": true,
+	"figure": true, "figcaption": true, "footer": true, "h1": true, "h2": true,
+	"h3": true, "h4": true, "h5": true, "h6": true, "header": true, "hr": true,
+	"li": true, "ol": true, "p": true, "pre": true, "section": true, "ul": true,
+}
+
+// renderer turns article HTML into wrapped, styled text. Links are numbered
+// and listed as footnotes after the text.
+type renderer struct {
+	links []string
+	// afterSpace is set when the inline text rendered so far is empty or ends
+	// with whitespace, so that the next text drops its leading whitespace
+	// even when an element starts or ends in between.
+	afterSpace bool
+}
+
+// RenderHTML renders content to text wrapped to width.
+func RenderHTML(content string, width int) (string, error) {
+	root, err := html.Parse(strings.NewReader(content))
+	if err != nil {
+		return "", err
+	}
+
+	body := findBody(root)
+	if body == nil {
+		body = root
+	}
+
+	r := renderer{}
+	blocks := r.renderChildren(body, pkg.Max(width, 10))
+	if len(r.links) > 0 {
+		footnotes := []string{headingStyle.Render("Links")}
+		for i, link := range r.links {
+			footnotes = append(footnotes, faintStyle.Render(fmt.Sprintf("[%d] %s", i+1, link)))
+		}
+		blocks = append(blocks, strings.Join(footnotes, "\n"))
+	}
+
+	return strings.Join(blocks, "\n\n"), nil
+}
+
+// renderChildren renders the children of n as blocks, gathering runs of
+// inline content into paragraphs.
+func (r *renderer) renderChildren(n *html.Node, width int) []string {
+	var blocks []string
+	inline := strings.Builder{}
+	r.afterSpace = true
+	flush := func() {
+		text := strings.TrimSpace(inline.String())
+		inline.Reset()
+		r.afterSpace = true
+		if text != "" {
+			blocks = append(blocks, wrap(text, width))
+		}
+	}
+
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type == html.ElementNode && blockTags[c.Data] {
+			flush()
+			if block := r.renderBlock(c, width); block != "" {
+				blocks = append(blocks, block)
+			}
+			continue
+		}
+		inline.WriteString(r.renderInline(c))
+	}
+	flush()
+
+	return blocks
+}
+
+func (r *renderer) renderBlock(n *html.Node, width int) string {
+	r.afterSpace = true
+	switch n.Data {
+	case "h1", "h2", "h3", "h4", "h5", "h6":
+		return headingStyle.Render(wrap(strings.TrimSpace(r.renderInlineChildren(n)), width))
+	case "hr":
+		return faintStyle.Render(strings.Repeat("─", width))
+	case "pre":
+		code := strings.ReplaceAll(strings.Trim(textContent(n), "\n"), "\t", "    ")
+		return codeBlockStyle.Copy().MaxWidth(width).Render(code)
+	case "blockquote":
+		inner := strings.Join(r.renderChildren(n, width-lipgloss.Width(quoteBar)), "\n\n")
+		lines := strings.Split(inner, "\n")
+		for i, line := range lines {
+			lines[i] = quoteBar + italicStyle.Render(line)
+		}
+		return strings.Join(lines, "\n")
+	case "ul", "ol":
+		return r.renderList(n, width)
+	case "figcaption":
+		return faintStyle.Render(wrap(strings.TrimSpace(r.renderInlineChildren(n)), width))
+	default:
+		return strings.Join(r.renderChildren(n, width), "\n\n")
+	}
+}
+
+func (r *renderer) renderList(n *html.Node, width int) string {
+	var items []string
+	number := 1
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type != html.ElementNode || c.Data != "li" {
+			continue
+		}
+
+		marker := "• "
+		if n.Data == "ol" {
+			marker = fmt.Sprintf("%d. ", number)
+			number++
+		}
+		indent := strings.Repeat(" ", lipgloss.Width(marker))
+		lines := strings.Split(strings.Join(r.renderChildren(c, width-len(indent)), "\n"), "\n")
+		for i := range lines {
+			if i == 0 {
+				lines[i] = marker + lines[i]
+			} else {
+				lines[i] = indent + lines[i]
+			}
+		}
+		items = append(items, strings.Join(lines, "\n"))
+	}
+
+	return strings.Join(items, "\n")
+}
+
+func (r *renderer) renderInline(n *html.Node) string {
+	if n.Type == html.TextNode {
+		text := spaceRegexp.ReplaceAllString(n.Data, " ")
+		if r.afterSpace {
+			text = strings.TrimLeft(text, " ")
+		}
+		if text != "" {
+			r.afterSpace = strings.HasSuffix(text, " ")
+		}
+		return text
+	}
+	if n.Type != html.ElementNode {
+		return r.renderInlineChildren(n)
+	}
+
+	switch n.Data {
+	case "strong", "b":
+		return boldStyle.Render(r.renderInlineChildren(n))
+	case "em", "i":
+		return italicStyle.Render(r.renderInlineChildren(n))
+	case "code":
+		code := textContent(n)
+		if code == "" {
+			return ""
+		}
+		r.afterSpace = false
+		return inlineCodeStyle.Render(code)
+	case "br":
+		r.afterSpace = true
+		return "\n"
+	case "a":
+		text := r.renderInlineChildren(n)
+		href := attr(n, "href")
+		if href == "" {
+			return text
+		}
+		r.afterSpace = false
+		return linkStyle.Render(text) + r.footnote(href)
+	case "img":
+		src := attr(n, "src")
+		label := "[image]"
+		if alt := strings.TrimSpace(attr(n, "alt")); alt != "" {
+			label = fmt.Sprintf("[image: %s]", alt)
+		}
+		r.afterSpace = false
+		if src == "" {
+			return faintStyle.Render(label)
+		}
+		return faintStyle.Render(label) + r.footnote(src)
+	case "script", "style":
+		return ""
+	default:
+		return r.renderInlineChildren(n)
+	}
+}
+
+func (r *renderer) renderInlineChildren(n *html.Node) string {
+	s := strings.Builder{}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		s.WriteString(r.renderInline(c))
+	}
+	return s.String()
+}
+
+func (r *renderer) footnote(link string) string {
+	for i, existing := range r.links {
+		if existing == link {
+			return faintStyle.Render(fmt.Sprintf("[%d]", i+1))
+		}
+	}
+	r.links = append(r.links, link)
+	return faintStyle.Render(fmt.Sprintf("[%d]", len(r.links)))
+}
+
+// wrap breaks text into lines of at most width cells, keeping explicit line
+// breaks.
+func wrap(text string, width int) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(line)
+	}
+	return lipgloss.NewStyle().Width(pkg.Max(width, 1)).Render(strings.Join(lines, "\n"))
+}
+
+// textContent returns the text of n as is, for code where whitespace matters.
+func textContent(n *html.Node) string {
+	if n.Type == html.TextNode {
+		return n.Data
+	}
+	if n.Type == html.ElementNode && n.Data == "br" {
+		return "\n"
+	}
+
+	s := strings.Builder{}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		s.WriteString(textContent(c))
+	}
+	return s.String()
+}
+
+// findBody returns the body element html.Parse wraps fragments in.
+func findBody(n *html.Node) *html.Node {
+	if n.Type == html.ElementNode && n.Data == "body" {
+		return n
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if body := findBody(c); body != nil {
+			return body
+		}
+	}
+	return nil
+}
+
+func attr(n *html.Node, name string) string {
+	for _, a := range n.Attr {
+		if a.Key == name {
+			return a.Val
+		}
+	}
+	return ""
+}
@@ -0,0 +1,168 @@
+package reader
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+var ansiRegexp = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+// plain removes the styles and the padding of rendered text.
+func plain(rendered string) string {
+	lines := strings.Split(ansiRegexp.ReplaceAllString(rendered, ""), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+func TestRenderHTML(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		width   int
+		want    string
+	}{
+		{
+			name:    "collapses whitespace",
+			content: "<p>Hello   <b>big</b>\n\t   world</p>",
+			want:    "Hello big world",
+		},
+		{
+			name:    "collapses whitespace around elements",
+			content: "<p>a <b> b </b> c<em> d</em></p>",
+			want:    "a b c d",
+		},
+		{
+			name:    "drops leading whitespace of a paragraph",
+			content: "<p> <em> lead</em> text </p>",
+			want:    "lead text",
+		},
+		{
+			name:    "keeps line breaks",
+			content: "<p>first line<br> second line</p>",
+			want:    "first line\nsecond line",
+		},
+		{
+			name:    "separates paragraphs",
+			content: "<p>one</p>\n\n  <p>two</p>",
+			want:    "one\n\ntwo",
+		},
+		{
+			name:    "inline text around a block",
+			content: "<div>before <p>block</p> after</div>",
+			want:    "before\n\nblock\n\nafter",
+		},
+		{
+			name:    "wraps to the width",
+			content: "<p>The quick brown fox jumps over the lazy dog</p>",
+			width:   20,
+			want:    "The quick brown fox\njumps over the lazy\ndog",
+		},
+		{
+			name:    "entities",
+			content: "<p>caf&eacute; &amp; &lt;tag&gt; &#8212; &quot;q&quot;</p>",
+			want:    `café & <tag> — "q"`,
+		},
+		{
+			name:    "headings",
+			content: "<h1>Title</h1><p>Body</p><h3>Sub <em>title</em></h3>",
+			want:    "Title\n\nBody\n\nSub title",
+		},
+		{
+			name:    "unordered list",
+			content: "<ul>\n  <li>one</li>\n  <li>two</li>\n</ul>",
+			want:    "• one\n• two",
+		},
+		{
+			name:    "ordered list",
+			content: "<ol><li>first</li><li>second</li><li>third</li></ol>",
+			want:    "1. first\n2. second\n3. third",
+		},
+		{
+			name:    "nested lists",
+			content: "<ul><li>one<ol><li>a</li><li>b</li></ol></li><li>two<ul><li>deep<ul><li>deeper</li></ul></li></ul></li></ul>",
+			want:    "• one\n  1. a\n  2. b\n• two\n  • deep\n    • deeper",
+		},
+		{
+			name:    "wraps list items under their text",
+			content: "<ul><li>a list item longer than the width</li></ul>",
+			width:   16,
+			want:    "• a list item\n  longer than\n  the width",
+		},
+		{
+			name:    "blockquote",
+			content: "<blockquote><p>quoted</p><p>twice</p></blockquote>",
+			want:    "│ quoted\n│\n│ twice",
+		},
+		{
+			name:    "nested blockquotes",
+			content: "<blockquote>outer<blockquote>inner</blockquote></blockquote>",
+			want:    "│ outer\n│\n│ │ inner",
+		},
+		{
+			name:    "list in a blockquote",
+			content: "<blockquote><ul><li>quoted item</li></ul></blockquote>",
+			want:    "│ • quoted item",
+		},
+		{
+			name:    "keeps whitespace of preformatted text",
+			content: "<pre><code>func main() {\n\tfmt.Println(\"a  b\")\n}\n</code></pre>",
+			want:    "  func main() {\n      fmt.Println(\"a  b\")\n  }",
+		},
+		{
+			name:    "inline code",
+			content: "<p>run <code>go  test</code> now</p>",
+			want:    "run go  test now",
+		},
+		{
+			name:    "links as footnotes",
+			content: `<p>See <a href="https://a.example">this</a>, <a href="https://b.example">that</a> and <a href="https://a.example">this again</a>.</p>`,
+			width:   60,
+			want:    "See this[1], that[2] and this again[1].\n\nLinks\n[1] https://a.example\n[2] https://b.example",
+		},
+		{
+			name:    "links without href",
+			content: `<p><a name="anchor">plain</a> text</p>`,
+			want:    "plain text",
+		},
+		{
+			name:    "images",
+			content: `<figure><img src="https://cdn.example/a.png" alt=" A chart "><figcaption>Figure 1</figcaption></figure><p><img alt=""></p>`,
+			want:    "[image: A chart][1]\n\nFigure 1\n\n[image]\n\nLinks\n[1] https://cdn.example/a.png",
+		},
+		{
+			name:    "drops scripts and styles",
+			content: "<p>kept<script>alert(1)</script><style>p{}</style> text</p>",
+			want:    "kept text",
+		},
+		{
+			name:    "rule",
+			content: "<p>a</p><hr><p>b</p>",
+			width:   10,
+			want:    "a\n\n──────────\n\nb",
+		},
+		{
+			name:    "empty",
+			content: "",
+			want:    "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			width := test.width
+			if width == 0 {
+				width = 40
+			}
+			got, err := RenderHTML(test.content, width)
+			if err != nil {
+				t.Fatalf("RenderHTML() error = %v", err)
+			}
+			if plain(got) != test.want {
+				t.Errorf("RenderHTML() =\n%s\nwant\n%s", plain(got), test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package restsection
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/mehmetcantas/medium-cli/components/table"
+	"github.com/mehmetcantas/medium-cli/config"
+	"github.com/mehmetcantas/medium-cli/pkg"
+)
+
+// column renders one configured column of an item.
+type column struct {
+	config   config.ColumnConfig
+	template *template.Template
+}
+
+type Item struct {
+	Data    interface{}
+	Columns []column
+}
+
+func newColumns(columnConfigs []config.ColumnConfig) []column {
+	columns := make([]column, 0, len(columnConfigs))
+	for _, columnConfig := range columnConfigs {
+		c := column{config: columnConfig}
+		if columnConfig.Template != "" {
+			// Templates are checked when the config is validated.
+			c.template = template.Must(template.New(columnConfig.Title).Parse(columnConfig.Template))
+		}
+		columns = append(columns, c)
+	}
+	return columns
+}
+
+// newDecoder returns the decoder of responses holding the items at itemsPath.
+// Results must be *[]interface{}.
+func newDecoder(itemsPath string) func(data []byte, result interface{}) error {
+	return func(data []byte, result interface{}) error {
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		// Numbers are kept as written, so large ids are not shown as floats.
+		decoder.UseNumber()
+		var document interface{}
+		if err := decoder.Decode(&document); err != nil {
+			return err
+		}
+
+		value, ok := pkg.LookupPath(document, itemsPath)
+		if !ok {
+			return fmt.Errorf("no value at items path %q", itemsPath)
+		}
+		items, ok := value.([]interface{})
+		if !ok {
+			return fmt.Errorf("the value at items path %q is not an array", itemsPath)
+		}
+
+		*result.(*[]interface{}) = items
+		return nil
+	}
+}
+
+func (i *Item) ToTableRow() table.Row {
+	row := make(table.Row, 0, len(i.Columns))
+	for _, c := range i.Columns {
+		row = append(row, i.renderCell(c))
+	}
+	return row
+}
+
+func (i *Item) renderCell(c column) string {
+	if c.template != nil {
+		s := strings.Builder{}
+		if err := c.template.Execute(&s, i.Data); err != nil {
+			return fmt.Sprintf("error: %v", err)
+		}
+		return s.String()
+	}
+
+	value, ok := pkg.LookupPath(i.Data, c.config.Field)
+	if !ok {
+		return ""
+	}
+	return formatValue(value)
+}
+
+// formatValue shows scalars as they are and nested values as compact JSON.
+func formatValue(value interface{}) string {
+	switch value := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case json.Number:
+		return value.String()
+	case map[string]interface{}, []interface{}:
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Sprint(value)
+		}
+		return string(encoded)
+	default:
+		return fmt.Sprint(value)
+	}
+}
@@ -0,0 +1,46 @@
+package restsection
+
+import (
+	"github.com/mehmetcantas/medium-cli/components/placeholdersection"
+	"github.com/mehmetcantas/medium-cli/components/section"
+	"github.com/mehmetcantas/medium-cli/components/table"
+	"github.com/mehmetcantas/medium-cli/config"
+	"github.com/mehmetcantas/medium-cli/ui/screencontext"
+)
+
+const SectionType = "rest"
+
+var kind = section.Kind{
+	Type:       SectionType,
+	ItemLabel:  "Item",
+	EmptyState: "No items found",
+}
+
+type source struct {
+	placeholdersection.Fetcher
+	columns []column
+}
+
+func NewModel(id int, ctx *screencontext.ScreenContext, config config.SectionConfig, view config.ViewType) section.Model {
+	fetcher := placeholdersection.NewFetcher(ctx, config, func() interface{} { return &[]interface{}{} })
+	fetcher.Decode = newDecoder(config.ItemsPath)
+	return section.NewModel(id, ctx, config, view, kind, source{Fetcher: fetcher, columns: newColumns(config.Columns)})
+}
+
+func (s source) Columns() []table.Column {
+	columns := make([]table.Column, 0, len(s.columns))
+	for _, c := range s.columns {
+		columns = append(columns, table.Column{
+			Title: c.config.Title,
+			Width: c.config.Width,
+			Grow:  c.config.Grow,
+		})
+	}
+
+	return columns
+}
+
+func (s source) BuildRow(record interface{}, width int) table.Row {
+	itemModel := Item{Data: record, Columns: s.columns}
+	return itemModel.ToTableRow()
+}
@@ -0,0 +1,80 @@
+package search
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mehmetcantas/medium-cli/ui/screencontext"
+)
+
+// SearchHeight is the height of the search bar while it is shown.
+const SearchHeight = 1
+
+var (
+	promptStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("#3498db"))
+
+	searchStyle = lipgloss.NewStyle().
+			Padding(0, 1).
+			Height(SearchHeight).
+			MaxHeight(SearchHeight)
+
+	appliedStyle = lipgloss.NewStyle().Faint(true)
+)
+
+// Model is the input line above the table where the rows of the current
+// section are searched.
+type Model struct {
+	IsEditing bool
+	input     textinput.Model
+}
+
+func NewModel() Model {
+	input := textinput.New()
+	input.Prompt = "/"
+	input.PromptStyle = promptStyle
+	input.Placeholder = "search rows"
+
+	return Model{
+		IsEditing: false,
+		input:     input,
+	}
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+// Open starts editing query, the search of the current section.
+func (m *Model) Open(query string) tea.Cmd {
+	m.IsEditing = true
+	m.input.SetValue(query)
+	m.input.CursorEnd()
+	return m.input.Focus()
+}
+
+// Close stops editing, the search stays applied until it is cleared.
+func (m *Model) Close() {
+	m.IsEditing = false
+	m.input.Blur()
+}
+
+func (m Model) Value() string {
+	return m.input.Value()
+}
+
+// View shows the input while editing, and otherwise query, the search applied
+// to the current section.
+func (m Model) View(ctx screencontext.ScreenContext, query string) string {
+	style := searchStyle.Copy().Width(ctx.MainContentWidth).MaxWidth(ctx.MainContentWidth)
+	if m.IsEditing {
+		return style.Render(m.input.View())
+	}
+	clearHint := fmt.Sprintf("  %s to clear", ctx.Keys.Back.Help().Key)
+	return style.Render(promptStyle.Render("/") + query + appliedStyle.Render(clearHint))
+}
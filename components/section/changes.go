@@ -0,0 +1,184 @@
+package section
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mehmetcantas/medium-cli/components/table"
+	"github.com/mehmetcantas/medium-cli/pkg"
+)
+
+// Changes counts the rows a refresh added, changed and removed.
+type Changes struct {
+	New     int
+	Changed int
+	Removed int
+}
+
+func (c Changes) IsZero() bool {
+	return c == Changes{}
+}
+
+// String describes the changes as "3 new, 1 changed, 2 removed", leaving out
+// the counts that are zero.
+func (c Changes) String() string {
+	var parts []string
+	if c.New > 0 {
+		parts = append(parts, fmt.Sprintf("%d new", c.New))
+	}
+	if c.Changed > 0 {
+		parts = append(parts, fmt.Sprintf("%d changed", c.Changed))
+	}
+	if c.Removed > 0 {
+		parts = append(parts, fmt.Sprintf("%d removed", c.Removed))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// rowSnapshot is what the rows were before a fetch replaced them.
+type rowSnapshot struct {
+	// rows are the cells of the rows by record id.
+	rows map[string]table.Row
+	// size is the number of rows loaded then.
+	size int
+	// hasMore is set when the rows were only the first pages of the
+	// resource.
+	hasMore bool
+}
+
+// setRecords shows the rows of records and marks the ones that differ from
+// before the current fetch. Records without an id are never marked.
+func (m *Model) setRecords(records []interface{}) {
+	m.Records = records
+	m.rowIds = make([]string, len(records))
+	for i, record := range records {
+		if id, ok := pkg.RecordId(record); ok {
+			m.rowIds[i] = id
+		}
+	}
+	m.Table.SetRows(m.BuildRows())
+	m.markChanges()
+}
+
+// takeSnapshot keeps the loaded rows to compare the next ones with. A fetch
+// that failed or has not returned yet leaves no rows, the previous snapshot
+// is kept then.
+func (m *Model) takeSnapshot() {
+	if len(m.Table.Rows) == 0 {
+		return
+	}
+
+	snapshot := &rowSnapshot{
+		rows:    map[string]table.Row{},
+		size:    len(m.Table.Rows),
+		hasMore: m.HasMore,
+	}
+	for i, row := range m.Table.Rows {
+		if i < len(m.rowIds) && m.rowIds[i] != "" {
+			snapshot.rows[m.rowIds[i]] = row
+		}
+	}
+	m.snapshot = snapshot
+}
+
+// markChanges compares the rows with the snapshot, then adds them to it so
+// the pages and fresh rows that follow are only compared with what was shown
+// last. The rows a refresh adds or changes stay marked until the user moves
+// the cursor to them, even through the following refreshes.
+func (m *Model) markChanges() {
+	m.Changes = Changes{}
+	if m.snapshot == nil {
+		return
+	}
+	if m.newRows == nil {
+		m.newRows = map[string]bool{}
+		m.changedCells = map[string]map[int]bool{}
+	}
+
+	seen := map[string]bool{}
+	for i, row := range m.Table.Rows {
+		if i >= len(m.rowIds) || m.rowIds[i] == "" {
+			continue
+		}
+		id := m.rowIds[i]
+		seen[id] = true
+		previous, ok := m.snapshot.rows[id]
+		m.snapshot.rows[id] = row
+
+		if !ok {
+			// Past the pages loaded before, a row missing from the snapshot
+			// may just not have been loaded then.
+			if m.snapshot.hasMore && i >= m.snapshot.size {
+				continue
+			}
+			m.Changes.New++
+			m.newRows[id] = true
+			continue
+		}
+
+		changed := changedCells(previous, row)
+		if len(changed) == 0 {
+			continue
+		}
+		m.Changes.Changed++
+		if m.changedCells[id] == nil {
+			m.changedCells[id] = map[int]bool{}
+		}
+		for _, cell := range changed {
+			m.changedCells[id][cell] = true
+		}
+	}
+
+	// Rows missing from partly loaded rows may be on the following pages.
+	if !m.HasMore {
+		for id := range m.snapshot.rows {
+			if !seen[id] {
+				m.Changes.Removed++
+			}
+		}
+	}
+	m.syncRowChanges()
+}
+
+// changedCells returns the indices of the cells whose text differs.
+func changedCells(previous table.Row, row table.Row) []int {
+	var changed []int
+	for i, cell := range row {
+		if i >= len(previous) || table.StripStyles(previous[i]) != table.StripStyles(cell) {
+			changed = append(changed, i)
+		}
+	}
+	return changed
+}
+
+func (m *Model) syncRowChanges() {
+	changes := map[int]table.RowChange{}
+	for i, id := range m.rowIds {
+		if id == "" || (!m.newRows[id] && len(m.changedCells[id]) == 0) {
+			continue
+		}
+		changes[i] = table.RowChange{IsNew: m.newRows[id], ChangedCells: m.changedCells[id]}
+	}
+	m.Table.SetRowChanges(changes)
+}
+
+// MarkCurrRowRead clears the marks of the row under the cursor.
+func (m *Model) MarkCurrRowRead() {
+	rowId := m.Table.GetCurrItem()
+	if rowId < 0 || rowId >= len(m.rowIds) {
+		return
+	}
+	id := m.rowIds[rowId]
+	if !m.newRows[id] && m.changedCells[id] == nil {
+		return
+	}
+
+	delete(m.newRows, id)
+	delete(m.changedCells, id)
+	m.syncRowChanges()
+}
+
+// GetUnreadCount returns the number of rows marked as new.
+func (m *Model) GetUnreadCount() int {
+	return m.Table.CountNewRows()
+}
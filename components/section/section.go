@@ -1,23 +1,112 @@
 package section
 
 import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
 	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/mehmetcantas/medium-cli/components/constants"
 	"github.com/mehmetcantas/medium-cli/components/table"
 	"github.com/mehmetcantas/medium-cli/config"
+	"github.com/mehmetcantas/medium-cli/pkg"
+	"github.com/mehmetcantas/medium-cli/pkg/filter"
 	"github.com/mehmetcantas/medium-cli/ui/screencontext"
 )
 
+// nextPageThreshold is how many rows before the last loaded one the next page
+// is requested.
+const nextPageThreshold = 5
+
+var (
+	ContainerPadding = 1
+
+	containerStyle = lipgloss.NewStyle().
+			Padding(0, ContainerPadding)
+
+	spinnerStyle = lipgloss.NewStyle().Padding(0, 1)
+
+	EmptyStateStyle = lipgloss.NewStyle().
+			Faint(true).
+			PaddingLeft(1).
+			MarginBottom(1)
+
+	errorStyle = lipgloss.NewStyle().
+			PaddingLeft(1).
+			Foreground(lipgloss.AdaptiveColor{Light: "#c0392b", Dark: "#e74c3c"})
+
+	errorHintStyle = EmptyStateStyle.Copy().
+			MarginTop(1)
+
+	retryStyle = lipgloss.NewStyle().Faint(true)
+)
+
+// Model is a section of any type. The type supplies the columns, the cells
+// of the records and how they are fetched through its Source.
 type Model struct {
-	Id        int
+	SectionId int
 	Config    config.SectionConfig
 	Ctx       *screencontext.ScreenContext
 	Spinner   spinner.Model
 	IsLoading bool
 	Table     table.Model
 	Type      string
+	ViewType  config.ViewType
+	Err       error
+	// Records are the loaded records, in the order of the table's rows.
+	Records []interface{}
+	source  Source
+	// nextPage is the page following the loaded records, nil for the last
+	// one.
+	nextPage interface{}
+	// Filter is the query the loaded rows must match, nil when every row is
+	// shown.
+	Filter *filter.Query
+	// Generation is bumped on every fetch so that responses of superseded
+	// fetches can be recognised and dropped.
+	Generation int
+	cancel     context.CancelFunc
+	// RetryAttempt is the attempt the current fetch is on once it had to be
+	// retried, and zero otherwise.
+	RetryAttempt     int
+	RetryMaxAttempts int
+	// Stale is set while the rows come from the cache and may be outdated.
+	Stale bool
+	// FetchedAt is when the shown rows were received from the server.
+	FetchedAt time.Time
+	// IsRefreshing is set while the shown rows are fetched again, they stay
+	// until the fresh ones arrive.
+	IsRefreshing bool
+	// RefreshErr is the error of a failed revalidation of stale rows or
+	// refresh of the shown ones.
+	RefreshErr error
+	// Offline is set when the rows were read from the cache without trying
+	// the network.
+	Offline bool
+	// Total is the number of rows of the whole resource, or -1 when unknown.
+	Total int
+	// HasMore is set when the resource has rows past the loaded pages.
+	HasMore       bool
+	IsLoadingMore bool
+	LoadMoreErr   error
+	events        chan tea.Msg
+	// Changes counts what the last fetch changed in the rows, zero for the
+	// first one.
+	Changes Changes
+	// rowIds are the ids of the records of the rows, empty for records
+	// without one.
+	rowIds []string
+	// snapshot is what the rows were before the current fetch, nil before
+	// the first rows arrive.
+	snapshot *rowSnapshot
+	// newRows and changedCells mark the rows, by record id, added and
+	// changed since the user last moved the cursor to them.
+	newRows      map[string]bool
+	changedCells map[string]map[int]bool
 }
 
 type Section interface {
@@ -29,10 +118,63 @@ type Section interface {
 	NextRow() int
 	PrevRow() int
 	FetchSectionRows() tea.Cmd
+	// RefreshSectionRows fetches the rows again, asking the server even for
+	// records cached within the TTL.
+	RefreshSectionRows() tea.Cmd
+	FetchNextPageRows() tea.Cmd
 	GetIsLoading() bool
 	GetSectionColumns() []table.Column
 	BuildRows() []table.Row
 	UpdateScreenContext(ctx *screencontext.ScreenContext)
+	GetFetchedAt() time.Time
+	// Search shows only the rows matching query, without fetching them
+	// again.
+	Search(query string)
+	GetSearch() string
+	NextSortColumn()
+	FlipSortDirection()
+	// GetShownRows returns the records of the shown rows along with their
+	// cells, in the order they are shown.
+	GetShownRows() ([]interface{}, []table.Row)
+	// GetCursor returns the position of the cursor among the shown rows.
+	GetCursor() int
+	SetCursor(position int)
+	// NextNewRow moves the cursor to the next row added by a refresh, and
+	// reports whether there was one.
+	NextNewRow() bool
+	// MarkCurrRowRead clears the marks of the row under the cursor.
+	MarkCurrRowRead()
+	// GetChanges returns what the last fetch changed in the rows.
+	GetChanges() Changes
+	// GetUnreadCount returns the number of rows added by refreshes that the
+	// user did not move the cursor to yet.
+	GetUnreadCount() int
+}
+
+// FetchResult describes how a fetch ended. It is embedded in the fetched
+// message of sections.
+type FetchResult struct {
+	Generation int
+	// Stale is set for cached rows that are being revalidated.
+	Stale bool
+	// Offline is set for cached rows served without trying the network.
+	Offline   bool
+	FetchedAt time.Time
+	Err       error
+	// Append is set for the rows of a page following the loaded ones.
+	Append  bool
+	Total   int
+	HasMore bool
+}
+
+func (r FetchResult) GetFetchResult() FetchResult {
+	return r
+}
+
+// FetchedMsg is implemented by the fetched message of sections.
+type FetchedMsg interface {
+	SectionMsg
+	GetFetchResult() FetchResult
 }
 
 func (m *Model) CreateNextTickCmd(nextTickCmd tea.Cmd) tea.Cmd {
@@ -41,17 +183,321 @@ func (m *Model) CreateNextTickCmd(nextTickCmd tea.Cmd) tea.Cmd {
 	}
 	return func() tea.Msg {
 		return SectionTickMsg{
-			SectionId:       m.Id,
+			SectionId:       m.SectionId,
 			InternalTickMsg: nextTickCmd(),
 			Type:            m.Type,
+			View:            m.ViewType,
 		}
 	}
 }
 
+// BeginFetch cancels the fetch in flight, if any, and resets the section for a
+// new one. The returned context is cancelled when the fetch is superseded or
+// the program quits, and the generation must be sent back with the result.
+func (m *Model) BeginFetch() (context.Context, int) {
+	ctx, generation := m.startFetch()
+
+	m.Err = nil
+	m.RefreshErr = nil
+	m.IsRefreshing = false
+	m.IsLoading = true
+	m.Stale = false
+	m.Offline = false
+	m.Total = -1
+	m.HasMore = false
+	m.IsLoadingMore = false
+	m.LoadMoreErr = nil
+	m.RetryAttempt = 0
+	m.Table.ResetCurrItem()
+	m.rowIds = nil
+	m.Changes = Changes{}
+	m.Table.SetRowChanges(nil)
+	m.Table.SetRows(nil)
+
+	return ctx, generation
+}
+
+// BeginRefresh starts fetching the rows again like BeginFetch, but the shown
+// rows, their marks and the cursor stay until the fresh rows arrive. A failed
+// refresh keeps them, see OnFetched.
+func (m *Model) BeginRefresh() (context.Context, int) {
+	ctx, generation := m.startFetch()
+
+	m.RefreshErr = nil
+	m.IsRefreshing = true
+	m.IsLoadingMore = false
+	m.LoadMoreErr = nil
+	m.RetryAttempt = 0
+
+	return ctx, generation
+}
+
+// startFetch cancels the fetch in flight, if any, and returns the context and
+// generation of a new one.
+func (m *Model) startFetch() (context.Context, int) {
+	if m.cancel != nil {
+		m.cancel()
+	}
+
+	parent := context.Background()
+	if m.Ctx != nil && m.Ctx.Context != nil {
+		parent = m.Ctx.Context
+	}
+	ctx, cancel := context.WithCancel(parent)
+	m.cancel = cancel
+	m.Generation++
+	m.takeSnapshot()
+
+	return ctx, m.Generation
+}
+
+// ShouldFetchNextPage reports whether the cursor got close enough to the last
+// loaded row to fetch the next page, and nothing else is being fetched.
+func (m *Model) ShouldFetchNextPage() bool {
+	return m.HasMore &&
+		!m.IsLoading &&
+		!m.IsLoadingMore &&
+		m.cancel == nil &&
+		// The filter may leave no rows to move the cursor towards.
+		(m.Table.IsNearBottom(nextPageThreshold) || len(m.Table.Rows) == 0)
+}
+
+// NewFilter parses the query of the section's filters. Queries are checked
+// when the config is validated, an invalid one is ignored.
+func NewFilter(sectionConfig config.SectionConfig) *filter.Query {
+	query, err := filter.Parse(sectionConfig.GetQuery())
+	if err != nil {
+		log.Printf("Ignoring the filters of section %q: %v\n", sectionConfig.Title, err)
+		return nil
+	}
+	return query
+}
+
+// SortByDefault sorts the rows by the column of the section's sort setting.
+func (m *Model) SortByDefault() {
+	column, descending := m.Config.GetSort()
+	if column == "" {
+		return
+	}
+	if !m.Table.SortByTitle(column, descending) {
+		log.Printf("Section %q has no column %q to sort by\n", m.Config.Title, column)
+	}
+}
+
+func (m *Model) GetShownRows() ([]interface{}, []table.Row) {
+	var shownRecords []interface{}
+	var rows []table.Row
+	for _, rowId := range m.Table.GetVisibleRows() {
+		if rowId >= len(m.Records) {
+			continue
+		}
+		shownRecords = append(shownRecords, m.Records[rowId])
+		rows = append(rows, m.Table.Rows[rowId])
+	}
+	return shownRecords, rows
+}
+
+// BeginPageFetch starts fetching the page after the loaded rows. It shares
+// the generation of the fetch that loaded them so a refresh drops it.
+func (m *Model) BeginPageFetch() (context.Context, int) {
+	parent := context.Background()
+	if m.Ctx != nil && m.Ctx.Context != nil {
+		parent = m.Ctx.Context
+	}
+	ctx, cancel := context.WithCancel(parent)
+	m.cancel = cancel
+	m.IsLoadingMore = true
+	m.LoadMoreErr = nil
+
+	return ctx, m.Generation
+}
+
+// IsCurrentFetch reports whether a result with the given generation belongs to
+// the latest fetch of the section.
+func (m *Model) IsCurrentFetch(generation int) bool {
+	return generation == m.Generation
+}
+
+// EndFetch marks the current fetch as done and releases its context.
+func (m *Model) EndFetch(err error) {
+	m.IsLoading = false
+	m.IsRefreshing = false
+	m.Err = err
+	m.RetryAttempt = 0
+	m.events = nil
+	if m.cancel != nil {
+		m.cancel()
+		m.cancel = nil
+	}
+}
+
+// OnFetched updates the fetch state for a result of the current fetch. It
+// reports whether the result's rows should replace the shown ones, and returns
+// the command to keep listening when more results are on their way. A failed
+// revalidation or refresh keeps showing the rows it was to replace.
+func (m *Model) OnFetched(result FetchResult) (bool, tea.Cmd) {
+	if result.Append {
+		m.IsLoadingMore = false
+		m.EndFetch(nil)
+		if result.Err != nil {
+			m.LoadMoreErr = result.Err
+			return false, nil
+		}
+		m.Total = result.Total
+		m.HasMore = result.HasMore
+		return true, nil
+	}
+
+	if result.Err != nil && (m.IsRefreshing || m.Stale) {
+		m.EndFetch(nil)
+		m.RefreshErr = result.Err
+		return false, nil
+	}
+
+	m.Total = result.Total
+	m.HasMore = result.HasMore
+	if result.Stale {
+		m.IsLoading = false
+		m.Stale = true
+		m.FetchedAt = result.FetchedAt
+		return true, m.NextFetchEvent()
+	}
+
+	m.EndFetch(result.Err)
+	m.Stale = false
+	m.Offline = result.Offline
+	m.FetchedAt = result.FetchedAt
+	return true, nil
+}
+
+// IsRevalidating reports whether cached rows are shown while fresh ones are
+// being fetched.
+func (m *Model) IsRevalidating() bool {
+	return m.Stale && m.cancel != nil
+}
+
+// RunFetch returns a command running fetch in the background. Retries reported
+// by the client through the context are delivered as SectionRetryingMsg, and
+// fetch may emit early results, such as cached data, before returning its own
+// message. Each intermediate message must be followed by NextFetchEvent.
+func (m *Model) RunFetch(ctx context.Context, generation int, fetch func(ctx context.Context, emit func(tea.Msg)) tea.Msg) tea.Cmd {
+	sectionId, sectionType, view := m.SectionId, m.Type, m.ViewType
+	events := make(chan tea.Msg)
+	m.events = events
+
+	emit := func(msg tea.Msg) {
+		select {
+		case events <- msg:
+		case <-ctx.Done():
+		}
+	}
+
+	return func() tea.Msg {
+		fetchCtx := pkg.WithRetryNotify(ctx, func(attempt, maxAttempts int, err error) {
+			emit(SectionRetryingMsg{
+				SectionId:   sectionId,
+				Type:        sectionType,
+				View:        view,
+				Generation:  generation,
+				Attempt:     attempt,
+				MaxAttempts: maxAttempts,
+				Err:         err,
+			})
+		})
+
+		go func() {
+			defer close(events)
+			emit(fetch(fetchCtx, emit))
+		}()
+
+		return waitForFetchEvent(events)()
+	}
+}
+
+// NextFetchEvent returns a command waiting for the next message of the fetch
+// in flight.
+func (m *Model) NextFetchEvent() tea.Cmd {
+	if m.events == nil {
+		return nil
+	}
+	return waitForFetchEvent(m.events)
+}
+
+func waitForFetchEvent(events <-chan tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		msg, ok := <-events
+		if !ok {
+			return nil
+		}
+		return msg
+	}
+}
+
+// OnRetrying records the retry progress of the current fetch and keeps
+// listening for its next event.
+func (m *Model) OnRetrying(msg SectionRetryingMsg) tea.Cmd {
+	if !m.IsCurrentFetch(msg.Generation) {
+		return nil
+	}
+
+	m.RetryAttempt = msg.Attempt
+	m.RetryMaxAttempts = msg.MaxAttempts
+	return m.NextFetchEvent()
+}
+
+// pagerStatus describes how much of the resource is loaded and the freshness
+// of the shown rows.
+func (m *Model) pagerStatus() string {
+	var statuses []string
+	loaded := len(m.Table.Rows)
+	if searching := m.Table.GetSearch() != ""; searching || m.Filter != nil {
+		// The total of the server counts the rows the filter leaves out, so
+		// the search and the filter share a single count of matching rows.
+		matching := fmt.Sprintf("%d matching", loaded)
+		if searching {
+			matching = fmt.Sprintf("%d of %d matching", len(m.Table.GetVisibleRows()), loaded)
+		}
+		if m.HasMore {
+			matching += ", more available"
+		}
+		statuses = append(statuses, matching)
+	} else if m.Total > loaded {
+		statuses = append(statuses, fmt.Sprintf("loaded %d of ~%d", loaded, m.Total))
+	} else if m.HasMore {
+		statuses = append(statuses, fmt.Sprintf("loaded %d, more available", loaded))
+	}
+	if m.IsLoadingMore {
+		statuses = append(statuses, "loading more…")
+	} else if m.LoadMoreErr != nil {
+		statuses = append(statuses, "loading more failed")
+	}
+
+	if m.Offline {
+		statuses = append(statuses, fmt.Sprintf("offline, cached %s ago", pkg.HumanizeDuration(time.Since(m.FetchedAt))))
+	} else if m.Stale {
+		statuses = append(statuses, fmt.Sprintf("stale, cached %s ago", pkg.HumanizeDuration(time.Since(m.FetchedAt))))
+	} else if !m.IsLoading && m.Err == nil && !m.FetchedAt.IsZero() {
+		statuses = append(statuses, fmt.Sprintf("updated %s ago", pkg.HumanizeDuration(time.Since(m.FetchedAt))))
+	}
+	if m.IsRevalidating() || m.IsRefreshing {
+		statuses = append(statuses, "refreshing…")
+	} else if m.RefreshErr != nil {
+		statuses = append(statuses, "refresh failed")
+	}
+
+	return strings.Join(statuses, " · ")
+}
+
+func (m *Model) errorHint() string {
+	if m.Ctx.Offline {
+		return fmt.Sprintf("Press %s to go online and retry", m.Ctx.Keys.ToggleOffline.Help().Key)
+	}
+	return fmt.Sprintf("Press %s to retry", m.Ctx.Keys.Refresh.Help().Key)
+}
+
 func (m *Model) GetDimensions() constants.Dimensions {
 	return constants.Dimensions{
-		Width: m.Ctx.MainContentWidth - lipgloss.NewStyle().
-			Padding(0, 1).GetHorizontalPadding(),
+		Width:  m.Ctx.MainContentWidth - containerStyle.GetHorizontalPadding(),
 		Height: m.Ctx.MainContentHeight - 2,
 	}
 }
@@ -67,29 +513,99 @@ func (m *Model) UpdateScreenContext(ctx *screencontext.ScreenContext) {
 	}
 }
 
+func (m *Model) View() string {
+	var spinnerText string
+	if m.IsLoading {
+		loadingText := "Fetching data..."
+		if m.RetryAttempt > 0 {
+			loadingText += retryStyle.Render(fmt.Sprintf(" retrying (%d/%d)…", m.RetryAttempt, m.RetryMaxAttempts))
+		}
+		spinnerText = lipgloss.JoinHorizontal(lipgloss.Top, spinnerStyle.Copy().Render(m.Spinner.View()), loadingText)
+	}
+
+	if m.Err != nil {
+		spinnerText = lipgloss.JoinVertical(
+			lipgloss.Left,
+			errorStyle.Copy().Width(m.GetDimensions().Width).Render(fmt.Sprintf("Error while fetching data : %v", m.Err)),
+			errorHintStyle.Render(m.errorHint()),
+		)
+	}
+
+	m.Table.SetPagerStatus(m.pagerStatus())
+	return containerStyle.Copy().Render(m.Table.View(spinnerText))
+}
+
 type SectionMsg interface {
 	GetSectionId() int
 	GetSectionType() string
+	GetSectionView() config.ViewType
 }
 
+// SectionRowsFetchedMsg carries the records of a fetch back to their
+// section.
 type SectionRowsFetchedMsg struct {
+	SectionId int
+	Type      string
+	View      config.ViewType
+	Records   []interface{}
+	// Next is the page following these records, nil for the last one.
+	Next interface{}
+	FetchResult
+}
+
+func (msg SectionRowsFetchedMsg) GetSectionId() int {
+	return msg.SectionId
+}
+
+func (msg SectionRowsFetchedMsg) GetSectionType() string {
+	return msg.Type
+}
+
+func (msg SectionRowsFetchedMsg) GetSectionView() config.ViewType {
+	return msg.View
+}
+
+// SectionRetryingMsg reports that a fetch failed and is about to be retried.
+type SectionRetryingMsg struct {
 	SectionId   int
-	Placeholder []interface{}
+	Type        string
+	View        config.ViewType
+	Generation  int
+	Attempt     int
+	MaxAttempts int
+	Err         error
 }
 
-func (msg *SectionRowsFetchedMsg) GetSectionId() int {
+func (msg SectionRetryingMsg) GetSectionId() int {
 	return msg.SectionId
 }
 
+func (msg SectionRetryingMsg) GetSectionType() string {
+	return msg.Type
+}
+
+func (msg SectionRetryingMsg) GetSectionView() config.ViewType {
+	return msg.View
+}
+
 type SectionTickMsg struct {
 	SectionId       int
 	InternalTickMsg tea.Msg
 	Type            string
+	View            config.ViewType
+}
+
+func (msg SectionTickMsg) GetSectionId() int {
+	return msg.SectionId
 }
 
 func (msg SectionTickMsg) GetSectionType() string {
 	return msg.Type
 }
+
+func (msg SectionTickMsg) GetSectionView() config.ViewType {
+	return msg.View
+}
 func (m *Model) NextRow() int {
 	if m != nil && len(m.Table.Rows) > 1 {
 		return m.Table.NextItem()
@@ -103,6 +619,76 @@ func (m *Model) PrevRow() int {
 	return 0
 }
 
+func (m *Model) Id() int {
+	return m.SectionId
+}
+
+func (m *Model) NumRows() int {
+	return len(m.Records)
+}
+
+func (m *Model) GetCurrRow() interface{} {
+	currItem := m.Table.GetCurrItem()
+	if currItem < 0 || currItem >= len(m.Records) {
+		return nil
+	}
+	return m.Records[currItem]
+}
+
 func (m *Model) GetIsLoading() bool {
 	return m.IsLoading
 }
+
+func (m *Model) GetSectionColumns() []table.Column {
+	return m.source.Columns()
+}
+
+// BuildRows returns the cells of the records for the current width.
+func (m *Model) BuildRows() []table.Row {
+	var rows []table.Row
+	for _, record := range m.Records {
+		rows = append(rows, m.source.BuildRow(record, m.GetDimensions().Width))
+	}
+	return rows
+}
+
+func (m *Model) Search(query string) {
+	m.Table.Search(query)
+}
+
+func (m *Model) GetSearch() string {
+	return m.Table.GetSearch()
+}
+
+func (m *Model) GetCursor() int {
+	return m.Table.GetCursor()
+}
+
+func (m *Model) SetCursor(position int) {
+	m.Table.SetCursor(position)
+}
+
+func (m *Model) NextSortColumn() {
+	m.Table.NextSortColumn()
+}
+
+func (m *Model) FlipSortDirection() {
+	m.Table.FlipSortDirection()
+}
+
+func (m *Model) NextNewRow() bool {
+	return m.Table.NextNewRow()
+}
+
+func (m *Model) GetChanges() Changes {
+	return m.Changes
+}
+
+// GetFetchedAt returns when the shown rows were received from the server, or
+// the zero time when there are none.
+func (m *Model) GetFetchedAt() time.Time {
+	if m.Err != nil {
+		return time.Time{}
+	}
+	return m.FetchedAt
+}
@@ -0,0 +1,72 @@
+package section
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mehmetcantas/medium-cli/components/table"
+	"github.com/mehmetcantas/medium-cli/config"
+	"github.com/mehmetcantas/medium-cli/ui/screencontext"
+)
+
+// titleSource shows records, which are strings, in a single column.
+type titleSource struct{}
+
+func (titleSource) Columns() []table.Column {
+	return []table.Column{{Title: "Title"}}
+}
+
+func (titleSource) BuildRow(record interface{}, width int) table.Row {
+	return table.Row{record.(string)}
+}
+
+func (titleSource) Fetch(ctx context.Context, request FetchRequest, onStale func(Page)) (Page, error) {
+	return Page{}, nil
+}
+
+func newTestModel(filters string, records ...interface{}) Model {
+	ctx := &screencontext.ScreenContext{Config: &config.Config{}, MainContentWidth: 80, MainContentHeight: 20}
+	sectionConfig := config.SectionConfig{Title: "Posts", Filters: filters}
+	m := NewModel(0, ctx, sectionConfig, config.PlaceholderView, Kind{Type: "test", ItemLabel: "Post"}, titleSource{})
+	m.IsLoading = false
+	m.setRecords(records)
+	return m
+}
+
+func TestPagerStatusCountsMatchingRowsOnce(t *testing.T) {
+	records := []interface{}{"alpha", "beta", "gamma", "alphabet"}
+	tests := []struct {
+		name    string
+		filters string
+		search  string
+		total   int
+		hasMore bool
+		want    string
+	}{
+		{name: "all loaded", filters: "posts", total: 4, want: ""},
+		{name: "more on the server", filters: "posts", total: 10, want: "loaded 4 of ~10"},
+		{name: "filter", filters: "posts alpha", total: 10, want: "4 matching"},
+		{name: "search", filters: "posts", search: "alp", total: 4, want: "2 of 4 matching"},
+		{name: "search and filter", filters: "posts alpha", search: "alp", total: 10, want: "2 of 4 matching"},
+		{
+			name:    "search and filter with more pages",
+			filters: "posts alpha",
+			search:  "alp",
+			total:   10,
+			hasMore: true,
+			want:    "2 of 4 matching, more available",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m := newTestModel(test.filters, records...)
+			m.Total = test.total
+			m.HasMore = test.hasMore
+			m.Search(test.search)
+			if got := m.pagerStatus(); got != test.want {
+				t.Errorf("pagerStatus() = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
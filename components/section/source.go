@@ -0,0 +1,219 @@
+package section
+
+import (
+	"context"
+	"time"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mehmetcantas/medium-cli/components/table"
+	"github.com/mehmetcantas/medium-cli/config"
+	"github.com/mehmetcantas/medium-cli/pkg/filter"
+	"github.com/mehmetcantas/medium-cli/ui/screencontext"
+)
+
+// Source supplies what differs between section types: the columns of their
+// table, the cells of their records and how the records are fetched.
+type Source interface {
+	Columns() []table.Column
+	// BuildRow returns the cells of record for a table of the given width.
+	BuildRow(record interface{}, width int) table.Row
+	// Fetch fetches the page of records request asks for, leaving out the
+	// ones its filter does not match and giving up once ctx is cancelled.
+	// Cached records that are being revalidated are handed to onStale first,
+	// when it is not nil.
+	Fetch(ctx context.Context, request FetchRequest, onStale func(Page)) (Page, error)
+}
+
+// FetchRequest asks a source for a page of records.
+type FetchRequest struct {
+	// Page is the Next of the page fetched before, nil for the first one.
+	Page interface{}
+	// Offline serves the records from the cache only.
+	Offline bool
+	// Force asks the source for fresh records even when its cached ones are
+	// younger than the section's TTL.
+	Force bool
+	// Filter is the query the records must match, nil to keep them all.
+	Filter *filter.Query
+}
+
+// Page is a page of records along with where they came from.
+type Page struct {
+	Records []interface{}
+	// Next is the page following this one, nil for the last one.
+	Next interface{}
+	// Total is the number of records of the whole resource, or -1 when
+	// unknown.
+	Total     int
+	FetchedAt time.Time
+	// Stale is set for cached records that are being revalidated.
+	Stale bool
+	// Offline is set for cached records served without trying the network.
+	Offline bool
+}
+
+// Kind describes a section type.
+type Kind struct {
+	// Type is the name of the type, sent along with the section's messages.
+	Type string
+	// ItemLabel names one record in the pager, such as "Post".
+	ItemLabel string
+	// EmptyState is shown when the section has no records.
+	EmptyState string
+}
+
+// NewModel creates a section of the given kind showing the records of
+// source.
+func NewModel(id int, ctx *screencontext.ScreenContext, sectionConfig config.SectionConfig, view config.ViewType, kind Kind, source Source) Model {
+	m := Model{
+		SectionId: id,
+		Config:    sectionConfig,
+		Ctx:       ctx,
+		Spinner:   spinner.Model{Spinner: spinner.Moon},
+		Filter:    NewFilter(sectionConfig),
+		IsLoading: true,
+		Type:      kind.Type,
+		ViewType:  view,
+		Records:   []interface{}{},
+		source:    source,
+	}
+
+	m.Table = table.NewModel(
+		m.GetDimensions(),
+		source.Columns(),
+		m.BuildRows(),
+		kind.ItemLabel,
+		EmptyStateStyle.Render(kind.EmptyState),
+		sectionConfig.Title,
+	)
+	m.SortByDefault()
+
+	return m
+}
+
+func (m Model) Update(msg tea.Msg) (Section, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case SectionRowsFetchedMsg:
+		if !m.IsCurrentFetch(msg.Generation) {
+			return &m, nil
+		}
+
+		var replaceRows bool
+		replaceRows, cmd = m.OnFetched(msg.FetchResult)
+		if replaceRows {
+			records := msg.Records
+			if msg.Append {
+				records = append(m.Records, msg.Records...)
+			}
+			m.nextPage = msg.Next
+			m.setRecords(records)
+			if m.Filter != nil {
+				// Keep loading pages while the filter leaves too few rows
+				// to get near the end of them.
+				cmd = tea.Batch(cmd, m.FetchNextPageRows())
+			}
+		}
+	case SectionRetryingMsg:
+		cmd = m.OnRetrying(msg)
+	case SectionTickMsg:
+		if !m.IsLoading {
+			return &m, nil
+		}
+
+		var internalTickCmd tea.Cmd
+		m.Spinner, internalTickCmd = m.Spinner.Update(msg.InternalTickMsg)
+		cmd = m.CreateNextTickCmd(internalTickCmd)
+	}
+
+	return &m, cmd
+}
+
+func (m *Model) FetchSectionRows() tea.Cmd {
+	return m.fetchSectionRows(false)
+}
+
+// RefreshSectionRows fetches the rows again for a refresh, skipping the TTL
+// of cached ones. The shown rows stay until the fresh ones arrive, unless
+// there are none yet or the last fetch failed.
+func (m *Model) RefreshSectionRows() tea.Cmd {
+	if m == nil {
+		return nil
+	}
+	if m.IsLoading || m.Err != nil {
+		return m.fetchSectionRows(true)
+	}
+
+	ctx, generation := m.BeginRefresh()
+	return m.fetch(ctx, generation, FetchRequest{Force: true}, false)
+}
+
+func (m *Model) fetchSectionRows(force bool) tea.Cmd {
+	if m == nil {
+		return nil
+	}
+	m.Records = nil
+	m.nextPage = nil
+	ctx, generation := m.BeginFetch()
+
+	var cmds []tea.Cmd
+	cmds = append(cmds, m.CreateNextTickCmd(spinner.Tick))
+	cmds = append(cmds, m.fetch(ctx, generation, FetchRequest{Force: force}, false))
+
+	return tea.Batch(cmds...)
+}
+
+// FetchNextPageRows fetches the page following the loaded records once the
+// cursor gets close to the last one.
+func (m *Model) FetchNextPageRows() tea.Cmd {
+	if m == nil || m.nextPage == nil || !m.ShouldFetchNextPage() {
+		return nil
+	}
+	ctx, generation := m.BeginPageFetch()
+
+	return m.fetch(ctx, generation, FetchRequest{Page: m.nextPage}, true)
+}
+
+// fetch runs request with the section's filter and offline mode.
+func (m *Model) fetch(ctx context.Context, generation int, request FetchRequest, appendRows bool) tea.Cmd {
+	sectionId, sectionType, view := m.SectionId, m.Type, m.ViewType
+	request.Offline = m.Ctx.Offline
+	request.Filter = m.Filter
+	source := m.source
+	// Following pages are added below rows already shown, and refreshed rows
+	// are shown already, so there is no point showing a stale copy of them
+	// first.
+	withStale := !appendRows && !m.IsRefreshing
+
+	return m.RunFetch(ctx, generation, func(ctx context.Context, emit func(tea.Msg)) tea.Msg {
+		newMsg := func(page Page, err error) tea.Msg {
+			return SectionRowsFetchedMsg{
+				SectionId: sectionId,
+				Type:      sectionType,
+				View:      view,
+				Records:   page.Records,
+				Next:      page.Next,
+				FetchResult: FetchResult{
+					Generation: generation,
+					Stale:      page.Stale,
+					Offline:    page.Offline,
+					FetchedAt:  page.FetchedAt,
+					Err:        err,
+					Append:     appendRows,
+					Total:      page.Total,
+					HasMore:    page.Next != nil,
+				},
+			}
+		}
+
+		var onStale func(page Page)
+		if withStale {
+			onStale = func(page Page) { emit(newMsg(page, nil)) }
+		}
+
+		page, err := source.Fetch(ctx, request, onStale)
+		return newMsg(page, err)
+	})
+}
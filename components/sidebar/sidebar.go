@@ -0,0 +1,194 @@
+package sidebar
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mehmetcantas/medium-cli/pkg"
+	"github.com/mehmetcantas/medium-cli/ui/screencontext"
+)
+
+var (
+	blue = lipgloss.AdaptiveColor{Light: "#3498db", Dark: "#2980b9"}
+
+	sideBarStyle = lipgloss.NewStyle().
+			Padding(0, 2).
+			BorderLeft(true).
+			BorderStyle(lipgloss.NormalBorder()).
+			BorderForeground(lipgloss.AdaptiveColor{Light: blue.Light, Dark: "#3498db"})
+
+	fieldNameStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.AdaptiveColor{Light: "#3498db", Dark: "#E2E1ED"})
+
+	emptyStateStyle = lipgloss.NewStyle().Faint(true)
+
+	// nestedIndent is how far the fields of nested records are indented.
+	nestedIndent = 2
+)
+
+type Model struct {
+	IsOpen   bool
+	record   interface{}
+	width    int
+	viewport viewport.Model
+}
+
+func NewModel() Model {
+	return Model{
+		IsOpen:   false,
+		viewport: viewport.Model{},
+	}
+}
+
+func (m Model) View() string {
+	if !m.IsOpen || m.width <= 0 {
+		return ""
+	}
+
+	return sideBarStyle.Copy().
+		Width(m.width - sideBarStyle.GetHorizontalBorderSize()).
+		MaxWidth(m.width).
+		Height(m.viewport.Height).
+		MaxHeight(m.viewport.Height).
+		Render(m.viewport.View())
+}
+
+// SetRecord shows record, usually the row under the cursor, from the top.
+func (m *Model) SetRecord(record interface{}) {
+	m.record = record
+	m.syncContent()
+	m.viewport.GotoTop()
+}
+
+func (m *Model) ScrollDown() {
+	m.viewport.HalfViewDown()
+}
+
+func (m *Model) ScrollUp() {
+	m.viewport.HalfViewUp()
+}
+
+func (m *Model) UpdateScreenContext(ctx *screencontext.ScreenContext) {
+	width := ctx.ScreenWidth - ctx.MainContentWidth
+	if width != m.width || ctx.MainContentHeight != m.viewport.Height {
+		m.width = width
+		m.viewport.Width = m.getContentWidth()
+		m.viewport.Height = ctx.MainContentHeight
+		m.syncContent()
+	}
+}
+
+func (m *Model) getContentWidth() int {
+	return m.width - sideBarStyle.GetHorizontalFrameSize()
+}
+
+func (m *Model) syncContent() {
+	width := m.getContentWidth()
+	if width <= 0 {
+		return
+	}
+
+	if m.record == nil {
+		m.viewport.SetContent(emptyStateStyle.Render("Nothing selected"))
+		return
+	}
+
+	m.viewport.SetContent(renderValue(reflect.ValueOf(m.record), width, 0))
+}
+
+// renderValue lists the fields of records, using their JSON names, and
+// wraps other values to width.
+func renderValue(value reflect.Value, width int, indent int) string {
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return ""
+		}
+		value = value.Elem()
+	}
+
+	if _, ok := value.Interface().(fmt.Stringer); ok {
+		return renderText(value, width, indent)
+	}
+
+	switch value.Kind() {
+	case reflect.Map:
+		keys := value.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+		})
+		lines := make([]string, 0, len(keys))
+		for _, key := range keys {
+			lines = append(lines, renderField(fmt.Sprint(key.Interface()), value.MapIndex(key), width, indent))
+		}
+		return strings.Join(lines, "\n")
+	case reflect.Struct:
+		lines := make([]string, 0, value.NumField())
+		for i := 0; i < value.NumField(); i++ {
+			field := value.Type().Field(i)
+			if field.PkgPath != "" {
+				continue
+			}
+			lines = append(lines, renderField(fieldName(field), value.Field(i), width, indent))
+		}
+		return strings.Join(lines, "\n")
+	case reflect.Slice, reflect.Array:
+		items := make([]string, 0, value.Len())
+		for i := 0; i < value.Len(); i++ {
+			items = append(items, renderField(fmt.Sprintf("%d", i+1), value.Index(i), width, indent))
+		}
+		return strings.Join(items, "\n")
+	default:
+		return renderText(value, width, indent)
+	}
+}
+
+func renderText(value reflect.Value, width int, indent int) string {
+	return lipgloss.NewStyle().Width(pkg.Max(width-indent, 1)).Render(fmt.Sprint(value.Interface()))
+}
+
+func renderField(name string, value reflect.Value, width int, indent int) string {
+	indentStyle := lipgloss.NewStyle().PaddingLeft(indent)
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return indentStyle.Render(fieldNameStyle.Render(name))
+		}
+		value = value.Elem()
+	}
+
+	title := indentStyle.Render(fieldNameStyle.Render(name))
+	if isNested(value) {
+		return lipgloss.JoinVertical(lipgloss.Left, title, renderValue(value, width, indent+nestedIndent))
+	}
+
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		title,
+		indentStyle.Render(renderValue(value, width, indent)),
+		"",
+	)
+}
+
+func isNested(value reflect.Value) bool {
+	if _, ok := value.Interface().(fmt.Stringer); ok {
+		return false
+	}
+	switch value.Kind() {
+	case reflect.Struct, reflect.Map, reflect.Slice, reflect.Array:
+		return true
+	default:
+		return false
+	}
+}
+
+func fieldName(field reflect.StructField) string {
+	name := strings.Split(field.Tag.Get("json"), ",")[0]
+	if name == "" || name == "-" {
+		return field.Name
+	}
+	return name
+}
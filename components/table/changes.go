@@ -0,0 +1,62 @@
+package table
+
+import "github.com/charmbracelet/lipgloss"
+
+var (
+	newRowBadge = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.AdaptiveColor{Light: "#27ae60", Dark: "#2ecc71"}).
+			Render("•")
+
+	changedCellStyle = lipgloss.NewStyle().
+				Italic(true).
+				Foreground(lipgloss.AdaptiveColor{Light: "#d35400", Dark: "#f39c12"})
+)
+
+// RowChange tells how a row differs from the rows shown before a refresh.
+type RowChange struct {
+	IsNew bool
+	// ChangedCells are the indices of the cells whose text changed.
+	ChangedCells map[int]bool
+}
+
+// SetRowChanges marks the rows of Rows by index, nil to clear every mark.
+func (m *Model) SetRowChanges(changes map[int]RowChange) {
+	m.rowChanges = changes
+	m.SyncViewPortContent()
+}
+
+// CountNewRows returns the number of rows marked as new.
+func (m *Model) CountNewRows() int {
+	count := 0
+	for _, change := range m.rowChanges {
+		if change.IsNew {
+			count++
+		}
+	}
+	return count
+}
+
+// NextNewRow moves the cursor to the next shown row marked as new, starting
+// over from the top after the last one. It reports whether there was one.
+func (m *Model) NextNewRow() bool {
+	visibleRows := m.GetVisibleRows()
+	cursor := m.GetCursor()
+	for i := 1; i <= len(visibleRows); i++ {
+		position := (cursor + i) % len(visibleRows)
+		if m.rowChanges[visibleRows[position]].IsNew {
+			m.SetCursor(position)
+			return true
+		}
+	}
+	return false
+}
+
+// renderChangedCell shows the plain text of a changed cell in the changed
+// style, unless the search highlights some of it.
+func (m *Model) renderChangedCell(cell string) string {
+	if m.search != "" && fuzzyMatch(StripStyles(cell), m.search) != nil {
+		return m.renderCellContent(cell)
+	}
+	return changedCellStyle.Render(StripStyles(cell))
+}
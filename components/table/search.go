@@ -0,0 +1,119 @@
+package table
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	ansiRegexp = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+	noMatchStyle = lipgloss.NewStyle().Faint(true).Padding(0, 1)
+
+	matchStyle = lipgloss.NewStyle().
+			Bold(true).
+			Underline(true).
+			Foreground(lipgloss.AdaptiveColor{Light: "#d35400", Dark: "#f39c12"})
+)
+
+// fuzzyMatch returns the positions of the runes of text matching the runes of
+// pattern in order, ignoring case, or nil when text does not match.
+func fuzzyMatch(text string, pattern string) []int {
+	patternRunes := []rune(strings.ToLower(pattern))
+	if len(patternRunes) == 0 {
+		return nil
+	}
+
+	var positions []int
+	i := 0
+	for position, r := range []rune(text) {
+		if unicode.ToLower(r) != patternRunes[i] {
+			continue
+		}
+		positions = append(positions, position)
+		i++
+		if i == len(patternRunes) {
+			return positions
+		}
+	}
+	return nil
+}
+
+// highlightMatch returns the plain text of cell with the runes at positions
+// highlighted.
+func highlightMatch(text string, positions []int) string {
+	s := strings.Builder{}
+	next := 0
+	for position, r := range []rune(text) {
+		if next < len(positions) && positions[next] == position {
+			s.WriteString(matchStyle.Render(string(r)))
+			next++
+			continue
+		}
+		s.WriteRune(r)
+	}
+	return s.String()
+}
+
+// StripStyles returns cell without the escape codes of its styles.
+func StripStyles(cell string) string {
+	return ansiRegexp.ReplaceAllString(cell, "")
+}
+
+// Search shows only the rows with a cell fuzzy matching query, from the first
+// one. An empty query shows every row again.
+func (m *Model) Search(query string) {
+	m.search = query
+	m.syncVisibleRows()
+	m.rowsViewPort.ResetCurrItem()
+	m.SyncViewPortContent()
+}
+
+func (m *Model) GetSearch() string {
+	return m.search
+}
+
+// syncVisibleRows finds the rows matching the search, in the sort order,
+// after a new search, sort or new rows.
+func (m *Model) syncVisibleRows() {
+	m.rowsViewPort.IsFiltered = m.search != ""
+
+	m.visibleRows = make([]int, 0, len(m.Rows))
+	for i, row := range m.Rows {
+		if m.search == "" {
+			m.visibleRows = append(m.visibleRows, i)
+			continue
+		}
+		for _, cell := range row {
+			if fuzzyMatch(StripStyles(cell), m.search) != nil {
+				m.visibleRows = append(m.visibleRows, i)
+				break
+			}
+		}
+	}
+	m.sortRows(m.visibleRows)
+	m.rowsViewPort.SetNumItems(len(m.visibleRows))
+}
+
+// GetVisibleRows returns the indices of the shown rows in Rows, in the order
+// they are shown.
+func (m *Model) GetVisibleRows() []int {
+	return m.visibleRows
+}
+
+// renderCellContent highlights the characters of cell matching the search.
+func (m *Model) renderCellContent(cell string) string {
+	if m.search == "" {
+		return cell
+	}
+
+	text := StripStyles(cell)
+	positions := fuzzyMatch(text, m.search)
+	if positions == nil {
+		return cell
+	}
+	return highlightMatch(text, positions)
+}
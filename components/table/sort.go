@@ -0,0 +1,142 @@
+package table
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	ascendingMarker  = " ▲"
+	descendingMarker = " ▼"
+)
+
+// SortBy orders the rows by the column at index, or in the order they arrived
+// when index is -1. The cursor stays on the row it was on.
+func (m *Model) SortBy(index int, descending bool) {
+	if index < -1 || index >= len(m.Columns) {
+		index = -1
+	}
+
+	currItem := m.GetCurrItem()
+	m.sortColumn = index
+	m.sortDescending = descending
+	m.syncVisibleRows()
+	m.setCurrItem(currItem)
+	m.SyncViewPortContent()
+}
+
+// SortByTitle orders the rows by the column with the given title. It reports
+// whether there is such a column.
+func (m *Model) SortByTitle(title string, descending bool) bool {
+	for i, column := range m.Columns {
+		if strings.EqualFold(column.Title, title) {
+			m.SortBy(i, descending)
+			return true
+		}
+	}
+	return false
+}
+
+// NextSortColumn sorts by the column after the current one, in ascending
+// order, and goes back to the arrival order after the last column.
+func (m *Model) NextSortColumn() {
+	next := m.sortColumn + 1
+	if next >= len(m.Columns) {
+		next = -1
+	}
+	m.SortBy(next, false)
+}
+
+// FlipSortDirection reverses the order of the sorted column, starting with
+// the first column when the rows are not sorted.
+func (m *Model) FlipSortDirection() {
+	if m.sortColumn < 0 {
+		m.SortBy(0, true)
+		return
+	}
+	m.SortBy(m.sortColumn, !m.sortDescending)
+}
+
+// sortRows orders rowIds, indices in Rows, by the sorted column. Rows with
+// equal cells keep the order they arrived in.
+func (m *Model) sortRows(rowIds []int) {
+	if m.sortColumn < 0 {
+		return
+	}
+
+	keys := make(map[int]string, len(rowIds))
+	for _, rowId := range rowIds {
+		if m.sortColumn < len(m.Rows[rowId]) {
+			keys[rowId] = StripStyles(m.Rows[rowId][m.sortColumn])
+		}
+	}
+
+	sort.SliceStable(rowIds, func(i, j int) bool {
+		comparison := compareCells(keys[rowIds[i]], keys[rowIds[j]])
+		if m.sortDescending {
+			return comparison > 0
+		}
+		return comparison < 0
+	})
+}
+
+// setCurrItem moves the cursor to the row at index in Rows, or to the first
+// row when it is not shown.
+func (m *Model) setCurrItem(rowId int) {
+	for position, visibleRowId := range m.GetVisibleRows() {
+		if visibleRowId == rowId {
+			m.rowsViewPort.SetCurrItem(position)
+			return
+		}
+	}
+	m.rowsViewPort.ResetCurrItem()
+}
+
+// renderColumnTitle returns the title of the column at index, with a marker
+// when the rows are sorted by it. The title is shortened to keep the marker
+// visible in width cells, unless width is zero.
+func (m *Model) renderColumnTitle(index int, width int) string {
+	title := m.Columns[index].Title
+	if index != m.sortColumn {
+		return title
+	}
+
+	marker := ascendingMarker
+	if m.sortDescending {
+		marker = descendingMarker
+	}
+	if width > 0 {
+		available := width - titleCellStyle.GetHorizontalPadding() - lipgloss.Width(marker)
+		if runes := []rune(title); available >= 0 && len(runes) > available {
+			title = string(runes[:available])
+		}
+	}
+	return title + marker
+}
+
+// compareCells orders numbers by value, so that 2 comes before 10, before any
+// text, which is ordered ignoring case.
+func compareCells(a, b string) int {
+	x, errA := strconv.ParseFloat(strings.TrimSpace(a), 64)
+	y, errB := strconv.ParseFloat(strings.TrimSpace(b), 64)
+	switch {
+	case errA == nil && errB == nil:
+		switch {
+		case x < y:
+			return -1
+		case x > y:
+			return 1
+		default:
+			return 0
+		}
+	case errA == nil:
+		return -1
+	case errB == nil:
+		return 1
+	}
+
+	return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+}
@@ -4,10 +4,14 @@ import (
 	"github.com/charmbracelet/lipgloss"
 	"github.com/mehmetcantas/medium-cli/components/constants"
 	"github.com/mehmetcantas/medium-cli/components/listviewport"
+	"github.com/mehmetcantas/medium-cli/pkg"
 )
 
 var (
-	SingleRuneWidth    = 4
+	SingleRuneWidth = 4
+	// minGrowCellWidth keeps growing columns readable when fixed ones already
+	// take the whole width.
+	minGrowCellWidth   = 6
 	MainContentPadding = 1
 
 	blue = lipgloss.AdaptiveColor{Light: "#3498db", Dark: "#2980b9"}
@@ -43,6 +47,17 @@ type Model struct {
 	EmptyState   string
 	dimensions   constants.Dimensions
 	rowsViewPort listviewport.Model
+	// search is the text rows are searched for, empty to show every row.
+	search string
+	// visibleRows are the indices of the rows matching the search, in the
+	// order they are shown.
+	visibleRows []int
+	// sortColumn is the index of the column rows are sorted by, -1 when they
+	// are shown in the order they arrived.
+	sortColumn     int
+	sortDescending bool
+	// rowChanges mark the rows by index that a refresh added or changed.
+	rowChanges map[int]RowChange
 }
 
 type Column struct {
@@ -54,13 +69,17 @@ type Column struct {
 type Row []string
 
 func NewModel(dimensions constants.Dimensions, columns []Column, rows []Row, itemTypeLabel string, emptyState string, tabName string) Model {
-	return Model{
+	m := Model{
 		Columns:      columns,
 		Rows:         rows,
 		EmptyState:   emptyState,
 		dimensions:   dimensions,
 		rowsViewPort: listviewport.NewModel(dimensions, itemTypeLabel, len(rows), 2, tabName),
+		sortColumn:   -1,
 	}
+	m.syncVisibleRows()
+
+	return m
 }
 
 func (m *Model) View(spinnerText string) string {
@@ -78,42 +97,79 @@ func (m *Model) SetDimensions(dimensions constants.Dimensions) {
 	})
 }
 
+// SetPagerStatus sets extra information shown next to the pager.
+func (m *Model) SetPagerStatus(status string) {
+	m.rowsViewPort.Status = status
+}
+
 func (m *Model) ResetCurrItem() {
 	m.rowsViewPort.ResetCurrItem()
 }
 
+// GetCurrItem returns the index in Rows of the row under the cursor, or -1
+// when no row is shown.
 func (m *Model) GetCurrItem() int {
+	visibleRows := m.GetVisibleRows()
+	currItem := m.rowsViewPort.GetCurrItem()
+	if currItem < 0 || currItem >= len(visibleRows) {
+		return -1
+	}
+	return visibleRows[currItem]
+}
+
+// GetCursor returns the position of the cursor among the shown rows.
+func (m *Model) GetCursor() int {
 	return m.rowsViewPort.GetCurrItem()
 }
 
+// SetCursor moves the cursor to position among the shown rows, or to the last
+// row when there are fewer of them.
+func (m *Model) SetCursor(position int) {
+	m.rowsViewPort.SetCurrItem(position)
+	m.SyncViewPortContent()
+}
+
+func (m *Model) IsNearBottom(threshold int) bool {
+	return m.rowsViewPort.IsNearBottom(threshold)
+}
+
 func (m *Model) PrevItem() int {
-	currItem := m.rowsViewPort.PrevItem()
+	m.rowsViewPort.PrevItem()
 	m.SyncViewPortContent()
 
-	return currItem
+	return m.GetCurrItem()
 }
 
 func (m *Model) NextItem() int {
-	currItem := m.rowsViewPort.NextItem()
+	m.rowsViewPort.NextItem()
 	m.SyncViewPortContent()
 
-	return currItem
+	return m.GetCurrItem()
 }
 
 func (m *Model) SyncViewPortContent() {
 	headerColumns := m.renderHeaderColumns()
-	renderedRows := make([]string, 0, len(m.Rows))
+	visibleRows := m.GetVisibleRows()
+	renderedRows := make([]string, 0, len(visibleRows))
 
-	for i := range m.Rows {
-		renderedRows = append(renderedRows, m.renderRow(i, headerColumns))
+	for position, rowId := range visibleRows {
+		renderedRows = append(renderedRows, m.renderRow(position, rowId, headerColumns))
+	}
+	if len(visibleRows) == 0 && m.search != "" {
+		renderedRows = append(renderedRows, noMatchStyle.Render("No rows match the search"))
 	}
 
 	m.rowsViewPort.SyncViewPort(lipgloss.JoinVertical(lipgloss.Left, renderedRows...))
 }
 
 func (m *Model) SetRows(rows []Row) {
+	currItem := m.GetCurrItem()
 	m.Rows = rows
-	m.rowsViewPort.SetNumItems(len(rows))
+	m.syncVisibleRows()
+	if m.sortColumn >= 0 {
+		// New rows may be sorted before the one under the cursor.
+		m.setCurrItem(currItem)
+	}
 	m.SyncViewPortContent()
 }
 
@@ -135,18 +191,18 @@ func (m *Model) renderHeaderColumns() []string {
 			continue
 		}
 		if column.Width != nil {
-			renderedColumns[i] = titleCellStyle.Copy().Width(*column.Width).MaxWidth(*column.Width).Render(column.Title)
+			renderedColumns[i] = titleCellStyle.Copy().Width(*column.Width).MaxWidth(*column.Width).Render(m.renderColumnTitle(i, *column.Width))
 
 			takenWidth += *column.Width
 			continue
 		}
 		if len(column.Title) == 1 {
 			takenWidth += SingleRuneWidth
-			renderedColumns[i] = singleRuneTitleCellStyle.Copy().Width(SingleRuneWidth).MaxWidth(SingleRuneWidth).Render(column.Title)
+			renderedColumns[i] = singleRuneTitleCellStyle.Copy().Width(SingleRuneWidth).MaxWidth(SingleRuneWidth).Render(m.renderColumnTitle(i, SingleRuneWidth))
 			continue
 		}
 
-		cell := titleCellStyle.Copy().Render(column.Title)
+		cell := titleCellStyle.Copy().Render(m.renderColumnTitle(i, 0))
 		renderedColumns[i] = cell
 		takenWidth += lipgloss.Width(cell)
 	}
@@ -156,14 +212,14 @@ func (m *Model) renderHeaderColumns() []string {
 		return renderedColumns
 	}
 
-	growCellWidth := leftoverWidth / numGrowingColumns
+	growCellWidth := pkg.Max(leftoverWidth/numGrowingColumns, minGrowCellWidth)
 
 	for i, column := range m.Columns {
 		if column.Grow == nil || !*column.Grow {
 			continue
 		}
 
-		renderedColumns[i] = titleCellStyle.Copy().Width(growCellWidth).MaxWidth(growCellWidth).Render(column.Title)
+		renderedColumns[i] = titleCellStyle.Copy().Width(growCellWidth).MaxWidth(growCellWidth).Render(m.renderColumnTitle(i, growCellWidth))
 	}
 
 	return renderedColumns
@@ -172,6 +228,7 @@ func (m *Model) renderHeaderColumns() []string {
 func (m *Model) renderHeader() string {
 	headerColumns := m.renderHeaderColumns()
 	header := lipgloss.JoinHorizontal(lipgloss.Top, headerColumns...)
+	header = lipgloss.NewStyle().MaxWidth(m.dimensions.Width).Render(header)
 	return headerStyle.Copy().Width(m.dimensions.Width).MaxWidth(m.dimensions.Width).Render(header)
 }
 
@@ -186,21 +243,36 @@ func (m *Model) renderBody(spinnerText string) string {
 	return m.rowsViewPort.View()
 }
 
-func (m *Model) renderRow(rowId int, headerColumns []string) string {
+// renderRow renders Rows[rowId], shown at position among the visible rows.
+func (m *Model) renderRow(position int, rowId int, headerColumns []string) string {
 	var style lipgloss.Style
-	if m.rowsViewPort.GetCurrItem() == rowId {
+	if m.rowsViewPort.GetCurrItem() == position {
 		style = selectedCellStyle
 	} else {
 		style = cellStyle
 	}
 
 	renderedColumns := make([]string, len(m.Columns))
+	change := m.rowChanges[rowId]
 
 	for i, column := range m.Rows[rowId] {
 		colWidth := lipgloss.Width(headerColumns[i])
-		col := style.Copy().Width(colWidth).MaxWidth(colWidth).Render(column)
-		renderedColumns = append(renderedColumns, col)
+		colStyle := style.Copy().Width(colWidth).MaxWidth(colWidth)
+		var content string
+		if change.ChangedCells[i] {
+			content = m.renderChangedCell(column)
+		} else {
+			content = m.renderCellContent(column)
+		}
+		if change.IsNew && i == 0 {
+			// The badge takes the place of the left padding so the cells
+			// stay aligned with the header.
+			colStyle = colStyle.PaddingLeft(0)
+			content = newRowBadge + content
+		}
+		renderedColumns = append(renderedColumns, colStyle.Render(content))
 	}
 
-	return rowStyle.Copy().Render(lipgloss.JoinHorizontal(lipgloss.Top, renderedColumns...))
+	// Columns wider than the table are cut rather than wrapped.
+	return rowStyle.Copy().MaxWidth(m.dimensions.Width).Render(lipgloss.JoinHorizontal(lipgloss.Top, renderedColumns...))
 }
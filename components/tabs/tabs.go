@@ -1,9 +1,13 @@
 package tabs
 
 import (
+	"fmt"
+	"time"
+
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/mehmetcantas/medium-cli/config"
+	"github.com/mehmetcantas/medium-cli/pkg"
 	"github.com/mehmetcantas/medium-cli/ui/screencontext"
 )
 
@@ -38,6 +42,17 @@ var (
 			Bold(true).
 			Background(lipgloss.AdaptiveColor{Light: blue.Light, Dark: "#39386b"})
 
+	offlineBanner = lipgloss.NewStyle().
+			Bold(true).
+			Padding(0, 1).
+			Background(lipgloss.AdaptiveColor{Light: "#f39c12", Dark: "#d35400"}).
+			Foreground(lipgloss.AdaptiveColor{Light: "#242347", Dark: "#E2E1ED"})
+
+	unreadBadge = lipgloss.NewStyle().
+			Bold(true).
+			Faint(false).
+			Foreground(lipgloss.AdaptiveColor{Light: "#27ae60", Dark: "#2ecc71"})
+
 	inactiveView = lipgloss.NewStyle().
 			MarginLeft(1).
 			Background(lipgloss.AdaptiveColor{Light: "#D9DCCF", Dark: "#2b2b40"}).
@@ -46,6 +61,12 @@ var (
 
 type Model struct {
 	CurrSectionId int
+	// DataFetchedAt is when the rows of the current section were received,
+	// shown in the offline banner.
+	DataFetchedAt time.Time
+	// UnreadCounts are the numbers of new rows not looked at yet in the
+	// sections, by section id.
+	UnreadCounts []int
 }
 
 func NewModel() Model {
@@ -67,6 +88,9 @@ func (m Model) View(ctx screencontext.ScreenContext) string {
 
 	var tabs []string
 	for i, sectionTitle := range sectionTitles {
+		if i < len(m.UnreadCounts) && m.UnreadCounts[i] > 0 {
+			sectionTitle = fmt.Sprintf("%s %s", sectionTitle, unreadBadge.Render(fmt.Sprintf("•%d", m.UnreadCounts[i])))
+		}
 		if m.CurrSectionId == i {
 			tabs = append(tabs, activeTab.Render(sectionTitle))
 		} else {
@@ -75,6 +99,9 @@ func (m Model) View(ctx screencontext.ScreenContext) string {
 	}
 
 	viewSwitcher := m.renderViewSwitcher(ctx)
+	if ctx.Offline {
+		viewSwitcher = lipgloss.JoinHorizontal(lipgloss.Top, m.renderOfflineBanner(), viewSwitcher)
+	}
 	tabsWidth := ctx.ScreenWidth - lipgloss.Width(viewSwitcher)
 	renderedTabs := lipgloss.NewStyle().
 		Width(tabsWidth).
@@ -90,18 +117,34 @@ func (m *Model) SetCurrSectionId(id int) {
 	m.CurrSectionId = id
 }
 
+func (m *Model) SetDataFetchedAt(fetchedAt time.Time) {
+	m.DataFetchedAt = fetchedAt
+}
+
+func (m *Model) SetUnreadCounts(counts []int) {
+	m.UnreadCounts = counts
+}
+
+func (m *Model) renderOfflineBanner() string {
+	text := "offline"
+	if !m.DataFetchedAt.IsZero() {
+		text = fmt.Sprintf("offline · data from %s ago", pkg.HumanizeDuration(time.Since(m.DataFetchedAt)))
+	}
+	return offlineBanner.Render(text)
+}
+
 func (m *Model) renderViewSwitcher(ctx screencontext.ScreenContext) string {
-	var placeholderStyle lipgloss.Style //,otherStyle
+	var placeholderStyle, otherStyle lipgloss.Style
 	if ctx.View == config.PlaceholderView {
 		placeholderStyle = activeView
-		//otherStyle = inactiveView
+		otherStyle = inactiveView
 	} else {
 		placeholderStyle = inactiveView
-		//otherStyle = activeView
+		otherStyle = activeView
 	}
 
-	placeholder := placeholderStyle.Render("[療Placeholder]")
-	//other := otherStyle.Render("[ﭦ Other]")
+	placeholder := placeholderStyle.Render("[療Placeholder]")
+	other := otherStyle.Render("[ﭦ Other]")
 	return viewSwitcher.Copy().
-		Render(lipgloss.JoinHorizontal(lipgloss.Top, placeholder)) //, other
+		Render(lipgloss.JoinHorizontal(lipgloss.Top, placeholder, other))
 }
@@ -0,0 +1,66 @@
+package usersection
+
+import (
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mehmetcantas/medium-cli/components/table"
+	"github.com/mehmetcantas/medium-cli/pkg"
+)
+
+type User struct {
+	Data  UserModel
+	Width int
+}
+
+type UserModel struct {
+	Id       int          `json:"id"`
+	Name     string       `json:"name"`
+	Username string       `json:"username"`
+	Email    string       `json:"email"`
+	Phone    string       `json:"phone"`
+	Website  string       `json:"website"`
+	Address  AddressModel `json:"address"`
+	Company  CompanyModel `json:"company"`
+}
+
+type AddressModel struct {
+	Street  string `json:"street"`
+	Suite   string `json:"suite"`
+	City    string `json:"city"`
+	Zipcode string `json:"zipcode"`
+}
+
+type CompanyModel struct {
+	Name        string `json:"name"`
+	CatchPhrase string `json:"catchPhrase"`
+	Bs          string `json:"bs"`
+}
+
+func (u *User) ToTableRow() table.Row {
+	return table.Row{
+		u.renderId(),
+		u.renderName(),
+		u.renderUsername(),
+		u.renderEmail(),
+		u.renderCompany(),
+	}
+}
+
+func (u *User) renderId() string {
+	return lipgloss.NewStyle().Render(pkg.CastIntToStr(u.Data.Id))
+}
+
+func (u *User) renderName() string {
+	return lipgloss.NewStyle().Render(u.Data.Name)
+}
+
+func (u *User) renderUsername() string {
+	return lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "#42A0FA", Dark: "#42A0FA"}).Render("@" + u.Data.Username)
+}
+
+func (u *User) renderEmail() string {
+	return lipgloss.NewStyle().Faint(true).Render(u.Data.Email)
+}
+
+func (u *User) renderCompany() string {
+	return lipgloss.NewStyle().Render(u.Data.Company.Name)
+}
@@ -0,0 +1,63 @@
+package usersection
+
+import (
+	"github.com/mehmetcantas/medium-cli/components/placeholdersection"
+	"github.com/mehmetcantas/medium-cli/components/section"
+	"github.com/mehmetcantas/medium-cli/components/table"
+	"github.com/mehmetcantas/medium-cli/config"
+	"github.com/mehmetcantas/medium-cli/ui/screencontext"
+)
+
+const SectionType = "users"
+
+var (
+	idCellWidth       = 8
+	usernameCellWidth = 20
+	emailCellWidth    = 30
+	growCell          = true
+)
+
+var kind = section.Kind{
+	Type:       SectionType,
+	ItemLabel:  "User",
+	EmptyState: "No users found",
+}
+
+type source struct {
+	placeholdersection.Fetcher
+}
+
+func NewModel(id int, ctx *screencontext.ScreenContext, config config.SectionConfig, view config.ViewType) section.Model {
+	fetcher := placeholdersection.NewFetcher(ctx, config, func() interface{} { return &[]UserModel{} })
+	return section.NewModel(id, ctx, config, view, kind, source{fetcher})
+}
+
+func (s source) Columns() []table.Column {
+	return []table.Column{
+		{
+			Title: "ID",
+			Width: &idCellWidth,
+		},
+		{
+			Title: "Name",
+			Grow:  &growCell,
+		},
+		{
+			Title: "Username",
+			Width: &usernameCellWidth,
+		},
+		{
+			Title: "Email",
+			Width: &emailCellWidth,
+		},
+		{
+			Title: "Company",
+			Grow:  &growCell,
+		},
+	}
+}
+
+func (s source) BuildRow(record interface{}, width int) table.Row {
+	userModel := User{Data: record.(UserModel), Width: width}
+	return userModel.ToTableRow()
+}
@@ -0,0 +1,116 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+	"text/template/parse"
+)
+
+// ActionConfig is a shell command run on the selected row of a section when
+// its key is pressed.
+type ActionConfig struct {
+	Key         string `yaml:"key"`
+	Description string `yaml:"description,omitempty"`
+	// Command is a template run on the selected record, e.g.
+	// code ./notes/{{.Title}}.md. Records come from the server, so every value
+	// the template inserts is quoted as a single shell word and cannot run
+	// commands of its own. Values must not be put in quotes of the template,
+	// where the quotes added around them would be kept as is.
+	Command string `yaml:"command"`
+	// Suspend hands the terminal over to the command, for interactive ones
+	// such as editors. Other commands run in the background.
+	Suspend bool `yaml:"suspend,omitempty"`
+}
+
+var commandFuncs = template.FuncMap{
+	"quote": shellQuote,
+}
+
+// GetDescription returns the description shown in the help, the command
+// when there is none.
+func (a ActionConfig) GetDescription() string {
+	if a.Description == "" {
+		return a.Command
+	}
+	return a.Description
+}
+
+// BuildCommand returns the shell command to run on record, with the values of
+// record quoted for the shell.
+func (a ActionConfig) BuildCommand(record interface{}) (string, error) {
+	tmpl, err := parseCommand(a.Command)
+	if err != nil {
+		return "", err
+	}
+	s := strings.Builder{}
+	if err := tmpl.Execute(&s, record); err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(s.String()), nil
+}
+
+func parseCommand(command string) (*template.Template, error) {
+	tmpl, err := template.New("command").Funcs(commandFuncs).Parse(command)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range tmpl.Templates() {
+		quoteActions(t.Tree.Root)
+	}
+	return tmpl, nil
+}
+
+// quoteActions pipes the value of every action under node through quote, the
+// way html/template escapes the values it inserts. Actions already ending with
+// quote are left alone.
+func quoteActions(node parse.Node) {
+	switch node := node.(type) {
+	case *parse.ListNode:
+		if node == nil {
+			return
+		}
+		for _, child := range node.Nodes {
+			quoteActions(child)
+		}
+	case *parse.ActionNode:
+		// Variable declarations print nothing.
+		if len(node.Pipe.Decl) > 0 || isQuoted(node.Pipe) {
+			return
+		}
+		quote := parse.NewIdentifier("quote").SetPos(node.Pos)
+		node.Pipe.Cmds = append(node.Pipe.Cmds, &parse.CommandNode{
+			NodeType: parse.NodeCommand,
+			Pos:      node.Pos,
+			Args:     []parse.Node{quote},
+		})
+	case *parse.IfNode:
+		quoteActions(node.List)
+		quoteActions(node.ElseList)
+	case *parse.RangeNode:
+		quoteActions(node.List)
+		quoteActions(node.ElseList)
+	case *parse.WithNode:
+		quoteActions(node.List)
+		quoteActions(node.ElseList)
+	}
+}
+
+// isQuoted reports whether the last command of pipe is quote.
+func isQuoted(pipe *parse.PipeNode) bool {
+	if len(pipe.Cmds) == 0 {
+		return false
+	}
+	last := pipe.Cmds[len(pipe.Cmds)-1]
+	if len(last.Args) == 0 {
+		return false
+	}
+	identifier, ok := last.Args[0].(*parse.IdentifierNode)
+	return ok && identifier.Ident == "quote"
+}
+
+// shellQuote quotes value as a single shell word.
+func shellQuote(value interface{}) string {
+	return "'" + strings.ReplaceAll(fmt.Sprint(value), "'", `'\''`) + "'"
+}
@@ -0,0 +1,96 @@
+package config
+
+import (
+	"os/exec"
+	"testing"
+)
+
+type actionRecord struct {
+	Id    int
+	Title string
+	Tags  []string
+}
+
+func TestBuildCommand(t *testing.T) {
+	tests := []struct {
+		name    string
+		command string
+		record  actionRecord
+		want    string
+	}{
+		{
+			name:    "plain value",
+			command: "code ./notes/{{.Title}}.md",
+			record:  actionRecord{Title: "notes"},
+			want:    "code ./notes/'notes'.md",
+		},
+		{
+			name:    "command substitution",
+			command: "echo {{.Title}}",
+			record:  actionRecord{Title: "$(touch pwned)"},
+			want:    "echo '$(touch pwned)'",
+		},
+		{
+			name:    "single quotes",
+			command: "echo {{.Title}}",
+			record:  actionRecord{Title: "it's; rm -rf ~"},
+			want:    `echo 'it'\''s; rm -rf ~'`,
+		},
+		{
+			name:    "explicit quote",
+			command: "echo {{quote .Title}} {{.Title | quote}}",
+			record:  actionRecord{Title: "a b"},
+			want:    "echo 'a b' 'a b'",
+		},
+		{
+			name:    "pipeline",
+			command: `echo {{printf "%d-%s" .Id .Title}}`,
+			record:  actionRecord{Id: 7, Title: "`id`"},
+			want:    "echo '7-`id`'",
+		},
+		{
+			name:    "control structures",
+			command: `{{if .Tags}}tag{{range .Tags}} {{.}}{{end}}{{else}}echo {{.Title}}{{end}}`,
+			record:  actionRecord{Tags: []string{"a;b", "c"}},
+			want:    "tag 'a;b' 'c'",
+		},
+		{
+			name:    "variables",
+			command: `{{$title := .Title}}{{with .Id}}open {{$title}} {{.}}{{end}}`,
+			record:  actionRecord{Id: 3, Title: "x|y"},
+			want:    "open 'x|y' '3'",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := ActionConfig{Command: test.command}.BuildCommand(test.record)
+			if err != nil {
+				t.Fatalf("BuildCommand() error = %v", err)
+			}
+			if got != test.want {
+				t.Errorf("BuildCommand() = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestBuildCommandRunsValuesAsWords(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("no sh to run the command")
+	}
+
+	for _, title := range []string{"$(echo injected)", "a; echo injected", "'; echo injected; '", "`echo injected`", "a\nb"} {
+		command, err := ActionConfig{Command: "printf %s {{.Title}}"}.BuildCommand(actionRecord{Title: title})
+		if err != nil {
+			t.Fatalf("BuildCommand() error = %v", err)
+		}
+		output, err := exec.Command("sh", "-c", command).Output()
+		if err != nil {
+			t.Fatalf("running %q: %v", command, err)
+		}
+		if string(output) != title {
+			t.Errorf("running %q printed %q, want %q", command, output, title)
+		}
+	}
+}
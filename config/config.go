@@ -1,5 +1,15 @@
 package config
 
+import (
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+)
+
 type ViewType string
 
 const (
@@ -7,52 +17,275 @@ const (
 	OtherView       ViewType = "other"
 )
 
+type SectionType string
+
+const (
+	PlaceholderSection SectionType = "placeholder"
+	CommentsSection    SectionType = "comments"
+	PhotosSection      SectionType = "photos"
+	UsersSection       SectionType = "users"
+	FeedSection        SectionType = "feed"
+	// RESTSection lists the items of any JSON API, with the columns given in
+	// the config.
+	RESTSection SectionType = "rest"
+)
+
+// PaginationType is how pages of a section's resource are requested.
+type PaginationType string
+
+const (
+	// PagePagination uses the _page and _limit query parameters.
+	PagePagination PaginationType = "page"
+	// RangePagination uses the _start and _end query parameters.
+	RangePagination PaginationType = "range"
+	// LinkPagination follows the rel="next" URL of the Link header.
+	LinkPagination PaginationType = "link"
+)
+
 type SectionConfig struct {
-	Title   string
-	Filters string
-	Limit   *int `yaml:"limit,omitempty"`
+	Title string      `yaml:"title"`
+	Type  SectionType `yaml:"type,omitempty"`
+	// Filters is the path of the resource requested from the source,
+	// optionally followed by a query matched against the loaded rows, e.g.
+	// todos completed:false -userId:1.
+	Filters string `yaml:"filters"`
+	// Source is the name of the source the section reads from, the default
+	// JSONPlaceholder one when empty.
+	Source string `yaml:"source,omitempty"`
+	// Limit is the page size. Sections without one load the whole resource,
+	// unless they use link pagination, whose page size the server picks.
+	Limit      *int           `yaml:"limit,omitempty"`
+	Pagination PaginationType `yaml:"pagination,omitempty"`
+	// ItemsPath is the dot separated path to the array of items in the
+	// responses of rest sections, e.g. data.items. The response is the array
+	// itself when empty.
+	ItemsPath string `yaml:"itemsPath,omitempty"`
+	// Columns are the table columns of rest sections.
+	Columns []ColumnConfig `yaml:"columns,omitempty"`
+	// Sort is the title of the column rows are sorted by at start, prefixed
+	// with - for descending order, e.g. -ID.
+	Sort string `yaml:"sort,omitempty"`
+	// Actions are the shell commands that can be run on the selected row.
+	Actions []ActionConfig `yaml:"actions,omitempty"`
+	// URLTemplate builds the address opened in the browser from the selected
+	// record, e.g. https://medium.com/p/{{.Id}}.
+	URLTemplate string `yaml:"urlTemplate,omitempty"`
+	// TTL is how long cached rows are used without asking the server again.
+	TTL time.Duration `yaml:"ttl,omitempty"`
+	// RefreshInterval is how often the rows are fetched again in the
+	// background, the default one when unset. Zero turns it off.
+	RefreshInterval *time.Duration `yaml:"refreshInterval,omitempty"`
+}
+
+// ColumnConfig describes a table column of a rest section. Its cells show the
+// value at Field, a dot separated path in the item, or the output of Template
+// run on the item, e.g. {{.name.first}} {{.name.last}}.
+type ColumnConfig struct {
+	Title    string `yaml:"title"`
+	Field    string `yaml:"field,omitempty"`
+	Template string `yaml:"template,omitempty"`
+	Width    *int   `yaml:"width,omitempty"`
+	Grow     *bool  `yaml:"grow,omitempty"`
 }
 
+// DefaultPreviewWidth is the width of the preview when neither the config
+// file nor the saved session state set one.
+const DefaultPreviewWidth = 50
+
 type PreviewConfig struct {
-	Open  bool
-	Width int
+	Open bool `yaml:"open"`
+	// Width is the width of the preview in percent of the screen. When unset
+	// the width saved with the session state is used.
+	Width *int `yaml:"width,omitempty"`
+}
+
+// GetWidth returns the configured width of the preview, or defaultWidth when
+// the config file does not set one.
+func (c PreviewConfig) GetWidth(defaultWidth int) int {
+	if c.Width == nil {
+		return defaultWidth
+	}
+	return *c.Width
 }
 
 type Defaults struct {
 	Preview PreviewConfig `yaml:"preview"`
 	View    ViewType      `yaml:"view"`
+	// RefreshInterval is the refresh interval of the sections without one,
+	// zero to only refresh them on demand.
+	RefreshInterval time.Duration `yaml:"refreshInterval,omitempty"`
+}
+
+// RetryConfig controls how failed requests are retried. Delays grow
+// exponentially from BaseDelay up to MaxDelay, with jitter.
+type RetryConfig struct {
+	MaxAttempts       int           `yaml:"maxAttempts"`
+	BaseDelay         time.Duration `yaml:"baseDelay"`
+	MaxDelay          time.Duration `yaml:"maxDelay"`
+	RetryableStatuses []int         `yaml:"retryableStatuses"`
+}
+
+type CacheConfig struct {
+	Enabled   bool `yaml:"enabled"`
+	MaxSizeMB int  `yaml:"maxSizeMB"`
+}
+
+// GetPath returns the server side part of the filters, the path of the
+// resource requested from the source.
+func (c SectionConfig) GetPath() string {
+	path, _, _ := splitFilters(c.Filters)
+	return path
+}
+
+// GetQuery returns the client side part of the filters, the query matched
+// against the loaded rows.
+func (c SectionConfig) GetQuery() string {
+	_, query, _ := splitFilters(c.Filters)
+	return query
+}
+
+// splitFilters splits filters at the first space. offset is where the query
+// starts in filters.
+func splitFilters(filters string) (path string, query string, offset int) {
+	trimmed := strings.TrimLeftFunc(filters, unicode.IsSpace)
+	start := len(filters) - len(trimmed)
+	end := strings.IndexFunc(trimmed, unicode.IsSpace)
+	if end < 0 {
+		return trimmed, "", len(filters)
+	}
+	return trimmed[:end], trimmed[end:], start + end
+}
+
+// GetSort returns the title of the column rows are sorted by at start, empty
+// when they are shown in the order they arrive.
+func (c SectionConfig) GetSort() (column string, descending bool) {
+	column = strings.TrimSpace(c.Sort)
+	if strings.HasPrefix(column, "-") {
+		return strings.TrimSpace(column[1:]), true
+	}
+	return column, false
+}
+
+// GetPagination returns the pagination style of the section, PagePagination
+// unless configured otherwise.
+func (c SectionConfig) GetPagination() PaginationType {
+	if c.Pagination == "" {
+		return PagePagination
+	}
+	return c.Pagination
+}
+
+// GetPageSize returns the configured page size, or zero when the section is
+// not paginated.
+func (c SectionConfig) GetPageSize() int {
+	if c.Limit == nil {
+		return 0
+	}
+	return *c.Limit
+}
+
+// GetRefreshInterval returns how often the section is refreshed in the
+// background, defaultInterval unless it has its own, or zero for never.
+func (c SectionConfig) GetRefreshInterval(defaultInterval time.Duration) time.Duration {
+	if c.RefreshInterval == nil {
+		return defaultInterval
+	}
+	return *c.RefreshInterval
+}
+
+// BuildURL returns the address of record for the browser, or an empty string
+// when the section has no URL template.
+func (c SectionConfig) BuildURL(record interface{}) (string, error) {
+	if c.URLTemplate == "" {
+		return "", nil
+	}
+
+	tmpl, err := template.New("url").Parse(c.URLTemplate)
+	if err != nil {
+		return "", err
+	}
+	s := strings.Builder{}
+	if err := tmpl.Execute(&s, record); err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(s.String()), nil
 }
 
 type Config struct {
-	PlaceholderSections []SectionConfig `yaml:"placeholderSections"`
-	OtherSections       []SectionConfig `yaml:"otherSections"`
-	Defaults            Defaults        `yaml:"defaults"`
+	Sources             map[string]SourceConfig `yaml:"sources"`
+	PlaceholderSections []SectionConfig         `yaml:"placeholderSections"`
+	OtherSections       []SectionConfig         `yaml:"otherSections"`
+	Defaults            Defaults                `yaml:"defaults"`
+	Retry               RetryConfig             `yaml:"retry"`
+	Cache               CacheConfig             `yaml:"cache"`
+	// Keybindings remap actions, by name, to other keys, e.g. quit: [q, x].
+	Keybindings map[string]KeyList `yaml:"keybindings,omitempty"`
 }
 
 type configError struct {
 	configDir string
+	path      string
+	line      int
+	column    int
 	parser    ConfigParser
 	err       error
 }
 
+func (e configError) Error() string {
+	location := e.path
+	if location == "" {
+		location = e.configDir
+	}
+	if e.line > 0 {
+		location = fmt.Sprintf("%s:%d", location, e.line)
+		if e.column > 0 {
+			location = fmt.Sprintf("%s:%d", location, e.column)
+		}
+	}
+
+	return fmt.Sprintf("config error in %s: %v", location, e.err)
+}
+
+func (e configError) Unwrap() error {
+	return e.err
+}
+
 type ConfigParser struct{}
 
 func (p ConfigParser) getDefaultConfig() Config {
 	return Config{
+		Sources: map[string]SourceConfig{
+			DefaultSourceName: {
+				BaseURL: "https://jsonplaceholder.typicode.com",
+			},
+			MediumSourceName: {
+				BaseURL: "https://medium.com",
+			},
+		},
 		Defaults: Defaults{
 			Preview: PreviewConfig{
-				Open:  true,
-				Width: 50,
+				Open: true,
 			},
 			View: PlaceholderView,
 		},
+		Retry: RetryConfig{
+			MaxAttempts:       3,
+			BaseDelay:         500 * time.Millisecond,
+			MaxDelay:          10 * time.Second,
+			RetryableStatuses: []int{408, 425, 429, 500, 502, 503, 504},
+		},
+		Cache: CacheConfig{
+			Enabled:   true,
+			MaxSizeMB: 50,
+		},
 		PlaceholderSections: []SectionConfig{
 			{
 				Title: "Albums",
 				// Buraya ön tanımlı filtrelerinizi yazabilirsiniz. Örneğin Github ile alakalı bir uygulama geliştiriyorsanız
 				// Kendi yarattığınız issue'ları görüntülemek isteyebilirsiniz o zaman bu tab için aşağıdaki gibi bir ön tanımlı filtre oluşturabilirsiniz
 
-				//is:open author:@me
+				//albums userId:1 -title:"quidem"
 				Filters: "albums",
 			},
 			{
@@ -63,24 +296,69 @@ func (p ConfigParser) getDefaultConfig() Config {
 		OtherSections: []SectionConfig{
 			{
 				Title:   "Comments",
-				Filters: "",
+				Type:    CommentsSection,
+				Filters: "comments",
+				Limit:   intPtr(100),
 			},
 			{
 				Title:   "Photos",
-				Filters: "",
+				Type:    PhotosSection,
+				Filters: "photos",
+				Limit:   intPtr(100),
 			},
 			{
 				Title:   "Users",
-				Filters: "",
+				Type:    UsersSection,
+				Filters: "users",
+			},
+			{
+				Title:   "Go on Medium",
+				Type:    FeedSection,
+				Filters: "feed/tag/golang",
 			},
 		},
 	}
 }
+func (c Config) GetViewSections(view ViewType) []SectionConfig {
+	if view == OtherView {
+		return c.OtherSections
+	}
+
+	return c.PlaceholderSections
+}
+
+func intPtr(i int) *int {
+	return &i
+}
+
 func initParser() ConfigParser {
 	return ConfigParser{}
 }
-func ParseConfig() (Config, error) {
+
+// ParseConfig loads the configuration file at location, or the first one found
+// in the default config directories when location is empty, on top of the
+// default configuration. Missing default files are not an error. Invalid
+// values are all reported together in a ValidationError.
+func ParseConfig(location string) (Config, error) {
 	parser := initParser()
 
-	return parser.getDefaultConfig(), nil
+	configFilePath, err := parser.getConfigFilePath(location)
+	if err != nil {
+		return Config{}, err
+	}
+
+	config := parser.getDefaultConfig()
+	var root *yaml.Node
+	if configFilePath != "" {
+		config, root, err = parser.readConfigFile(configFilePath)
+		if err != nil {
+			return config, err
+		}
+	}
+
+	if problems := parser.validate(config, root); len(problems) > 0 {
+		return config, ValidationError{File: configFilePath, Problems: problems}
+	}
+
+	return config, nil
 }
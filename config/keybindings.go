@@ -0,0 +1,33 @@
+package config
+
+import (
+	"github.com/mehmetcantas/medium-cli/pkg"
+	"gopkg.in/yaml.v3"
+)
+
+// KeyList is the keys bound to an action, written as a list or, for a single
+// key, as a string.
+type KeyList []string
+
+func (l *KeyList) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*l = KeyList{node.Value}
+		return nil
+	}
+
+	var keys []string
+	if err := node.Decode(&keys); err != nil {
+		return err
+	}
+	*l = keys
+	return nil
+}
+
+// GetKeyMap returns the default keys with the configured keybindings applied.
+func (c Config) GetKeyMap() (pkg.KeyMap, error) {
+	overrides := make(map[string][]string, len(c.Keybindings))
+	for name, keys := range c.Keybindings {
+		overrides[name] = keys
+	}
+	return pkg.NewKeyMap(overrides)
+}
@@ -0,0 +1,147 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	appDirName     = "medium-cli"
+	configFileName = "config.yml"
+)
+
+var yamlLineRegexp = regexp.MustCompile(`line (\d+):\s*`)
+
+// getConfigDirs returns the directories searched for a config file, in order
+// of precedence.
+func (p ConfigParser) getConfigDirs() []string {
+	var dirs []string
+	if xdgConfigHome := os.Getenv("XDG_CONFIG_HOME"); xdgConfigHome != "" {
+		dirs = append(dirs, filepath.Join(xdgConfigHome, appDirName))
+	}
+	if homeDir, err := os.UserHomeDir(); err == nil {
+		dirs = append(dirs, filepath.Join(homeDir, ".config", appDirName))
+	}
+
+	return dirs
+}
+
+func (p ConfigParser) getConfigFilePath(location string) (string, error) {
+	if location != "" {
+		if _, err := os.Stat(location); err != nil {
+			return "", configError{path: location, parser: p, err: err}
+		}
+		return location, nil
+	}
+
+	for _, dir := range p.getConfigDirs() {
+		configFilePath := filepath.Join(dir, configFileName)
+		_, err := os.Stat(configFilePath)
+		if err == nil {
+			return configFilePath, nil
+		}
+		if !errors.Is(err, os.ErrNotExist) {
+			return "", configError{configDir: dir, path: configFilePath, parser: p, err: err}
+		}
+	}
+
+	return "", nil
+}
+
+func (p ConfigParser) readConfigFile(path string) (Config, *yaml.Node, error) {
+	config := p.getDefaultConfig()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return config, nil, configError{configDir: filepath.Dir(path), path: path, parser: p, err: err}
+	}
+
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return config, nil, p.newParsingError(path, &root, err)
+	}
+	if len(root.Content) == 0 {
+		return config, &root, nil
+	}
+
+	if err := root.Decode(&config); err != nil {
+		return config, nil, p.newParsingError(path, &root, err)
+	}
+
+	return config, &root, nil
+}
+
+// newParsingError wraps a yaml error with the location it refers to. yaml only
+// reports lines, so the column is taken from the value of the key found on
+// that line, which is the offending value for type errors, or else from the
+// last node on that line.
+func (p ConfigParser) newParsingError(path string, root *yaml.Node, err error) configError {
+	cfgErr := configError{configDir: filepath.Dir(path), path: path, parser: p, err: err}
+
+	var typeErr *yaml.TypeError
+	message := err.Error()
+	if errors.As(err, &typeErr) && len(typeErr.Errors) > 0 {
+		message = typeErr.Errors[0]
+		cfgErr.err = fmt.Errorf("%s", yamlLineRegexp.ReplaceAllString(message, ""))
+		if len(typeErr.Errors) > 1 {
+			cfgErr.err = fmt.Errorf("%v (and %d more)", cfgErr.err, len(typeErr.Errors)-1)
+		}
+	}
+
+	if match := yamlLineRegexp.FindStringSubmatch(message); match != nil {
+		cfgErr.line, _ = strconv.Atoi(match[1])
+		cfgErr.column = findColumnAtLine(root, cfgErr.line)
+	}
+
+	return cfgErr
+}
+
+func findColumnAtLine(node *yaml.Node, line int) int {
+	if node == nil {
+		return 0
+	}
+	if valueNode := findValueAtLine(node, line); valueNode != nil {
+		return valueNode.Column
+	}
+
+	return findLastColumnAtLine(node, line)
+}
+
+// findValueAtLine returns the value of the last mapping key found on line.
+func findValueAtLine(node *yaml.Node, line int) *yaml.Node {
+	var value *yaml.Node
+	if node.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Line == line {
+				value = node.Content[i+1]
+			}
+		}
+	}
+	for _, child := range node.Content {
+		if childValue := findValueAtLine(child, line); childValue != nil {
+			value = childValue
+		}
+	}
+
+	return value
+}
+
+func findLastColumnAtLine(node *yaml.Node, line int) int {
+	column := 0
+	if node.Kind != yaml.DocumentNode && node.Line == line {
+		column = node.Column
+	}
+	for _, child := range node.Content {
+		if childColumn := findLastColumnAtLine(child, line); childColumn > 0 {
+			column = childColumn
+		}
+	}
+
+	return column
+}
@@ -0,0 +1,117 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeConfig writes data to a config file in a temporary directory and
+// returns its path.
+func writeConfig(t *testing.T, data string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), configFileName)
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestParseConfigLocatesYAMLErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		data        string
+		wantLine    int
+		wantColumn  int
+		wantMessage string
+	}{
+		{
+			name: "wrong type",
+			data: `placeholderSections:
+  - title: Posts
+    filters: posts
+    limit: ten
+`,
+			wantLine:    4,
+			wantColumn:  12,
+			wantMessage: "cannot unmarshal !!str `ten` into int",
+		},
+		{
+			name: "wrong type in a nested value",
+			data: `defaults:
+  preview:
+    open: maybe
+`,
+			wantLine:    3,
+			wantColumn:  11,
+			wantMessage: "cannot unmarshal !!str `maybe` into bool",
+		},
+		{
+			name: "several wrong types",
+			data: `placeholderSections:
+  - title: [Posts]
+    limit: ten
+`,
+			wantLine:    2,
+			wantColumn:  12,
+			wantMessage: "(and 1 more)",
+		},
+		{
+			// Syntax errors leave no tree to find the column in.
+			name: "bad indentation",
+			data: `placeholderSections:
+  - title: Posts
+     filters: posts
+`,
+			wantLine:    3,
+			wantMessage: "mapping values are not allowed in this context",
+		},
+		{
+			name: "unclosed quote",
+			data: `placeholderSections:
+  - title: "Posts
+`,
+			wantLine:    2,
+			wantMessage: "found unexpected end of stream",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path := writeConfig(t, test.data)
+			_, err := ParseConfig(path)
+			var cfgErr configError
+			if !errors.As(err, &cfgErr) {
+				t.Fatalf("ParseConfig() error = %v, want a configError", err)
+			}
+			if cfgErr.line != test.wantLine || cfgErr.column != test.wantColumn {
+				t.Errorf("error at %d:%d, want %d:%d", cfgErr.line, cfgErr.column, test.wantLine, test.wantColumn)
+			}
+			if !strings.Contains(err.Error(), test.wantMessage) {
+				t.Errorf("ParseConfig() error = %q, want it to contain %q", err, test.wantMessage)
+			}
+			if !strings.HasPrefix(err.Error(), "config error in "+path+":") {
+				t.Errorf("ParseConfig() error = %q, want it to start with the location", err)
+			}
+		})
+	}
+}
+
+func TestParseConfigMissingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing.yml")
+	if _, err := ParseConfig(path); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("ParseConfig() error = %v, want os.ErrNotExist", err)
+	}
+}
+
+func TestParseConfigEmptyFile(t *testing.T) {
+	config, err := ParseConfig(writeConfig(t, ""))
+	if err != nil {
+		t.Fatalf("ParseConfig() error = %v", err)
+	}
+	if len(config.PlaceholderSections) == 0 {
+		t.Error("ParseConfig() of an empty file has no sections, want the default ones")
+	}
+}
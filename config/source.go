@@ -0,0 +1,141 @@
+package config
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+const (
+	// DefaultSourceName is the source of sections that do not name one.
+	DefaultSourceName = "jsonplaceholder"
+	// MediumSourceName is the source of feed sections that do not name one.
+	MediumSourceName = "medium"
+)
+
+// SourceConfig describes an API the sections read from.
+type SourceConfig struct {
+	BaseURL string            `yaml:"baseURL"`
+	Headers map[string]string `yaml:"headers,omitempty"`
+	Auth    AuthConfig        `yaml:"auth,omitempty"`
+}
+
+// AuthConfig holds at most one way of authenticating to a source.
+type AuthConfig struct {
+	Bearer *SecretConfig    `yaml:"bearer,omitempty"`
+	Basic  *BasicAuthConfig `yaml:"basic,omitempty"`
+}
+
+type BasicAuthConfig struct {
+	Username string       `yaml:"username"`
+	Password SecretConfig `yaml:"password"`
+}
+
+// SecretConfig tells where a secret is read from, so that it never has to be
+// written in the config file. Commands run without a terminal and must not
+// prompt, e.g. `pass show` with a running gpg agent.
+type SecretConfig struct {
+	Env     string `yaml:"env,omitempty"`
+	Command string `yaml:"command,omitempty"`
+}
+
+// Source is a source whose secrets have been read, ready to send requests to.
+type Source struct {
+	Name    string
+	BaseURL string
+	Header  http.Header
+}
+
+func (s SecretConfig) resolve() (string, error) {
+	if s.Env != "" {
+		value, ok := os.LookupEnv(s.Env)
+		if !ok {
+			return "", fmt.Errorf("environment variable %s is not set", s.Env)
+		}
+		return value, nil
+	}
+
+	var stderr bytes.Buffer
+	cmd := exec.Command("sh", "-c", s.Command)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return "", fmt.Errorf("command %q failed: %v: %s", s.Command, err, message)
+		}
+		return "", fmt.Errorf("command %q failed: %v", s.Command, err)
+	}
+
+	// Like pass, most commands print the secret on the first line.
+	return strings.TrimSpace(strings.SplitN(string(out), "\n", 2)[0]), nil
+}
+
+func (c SourceConfig) resolve(name string) (Source, error) {
+	source := Source{
+		Name:    name,
+		BaseURL: strings.TrimSuffix(c.BaseURL, "/"),
+		Header:  http.Header{},
+	}
+	for key, value := range c.Headers {
+		source.Header.Set(key, value)
+	}
+
+	switch {
+	case c.Auth.Bearer != nil:
+		token, err := c.Auth.Bearer.resolve()
+		if err != nil {
+			return source, fmt.Errorf("bearer token: %w", err)
+		}
+		source.Header.Set("Authorization", "Bearer "+token)
+	case c.Auth.Basic != nil:
+		password, err := c.Auth.Basic.Password.resolve()
+		if err != nil {
+			return source, fmt.Errorf("basic auth password: %w", err)
+		}
+		credentials := base64.StdEncoding.EncodeToString([]byte(c.Auth.Basic.Username + ":" + password))
+		source.Header.Set("Authorization", "Basic "+credentials)
+	}
+
+	return source, nil
+}
+
+// GetSource returns the name of the source the section reads from.
+func (c SectionConfig) GetSource() string {
+	if c.Source == "" {
+		if c.Type == FeedSection {
+			return MediumSourceName
+		}
+		return DefaultSourceName
+	}
+	return c.Source
+}
+
+// ResolveSources reads the secrets of every source used by a section, running
+// the configured commands. Sources no section uses are left alone.
+func (c Config) ResolveSources() (map[string]Source, error) {
+	sources := map[string]Source{}
+	for _, sections := range [][]SectionConfig{c.PlaceholderSections, c.OtherSections} {
+		for _, section := range sections {
+			name := section.GetSource()
+			if _, ok := sources[name]; ok {
+				continue
+			}
+
+			sourceConfig, ok := c.Sources[name]
+			if !ok {
+				return nil, fmt.Errorf("section %q uses unknown source %q", section.Title, name)
+			}
+			source, err := sourceConfig.resolve(name)
+			if err != nil {
+				return nil, fmt.Errorf("source %q: %w", name, err)
+			}
+			sources[name] = source
+		}
+	}
+
+	return sources, nil
+}
@@ -0,0 +1,627 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/mehmetcantas/medium-cli/pkg"
+	"github.com/mehmetcantas/medium-cli/pkg/filter"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	knownViews        = []ViewType{PlaceholderView, OtherView}
+	knownSectionTypes = []SectionType{PlaceholderSection, CommentsSection, PhotosSection, UsersSection, FeedSection, RESTSection}
+	knownPaginations  = []PaginationType{PagePagination, RangePagination, LinkPagination}
+)
+
+// minRefreshInterval is the shortest interval sections can be refreshed at.
+const minRefreshInterval = time.Second
+
+// ValidationProblem describes a single invalid value or key in the config file.
+// Line and Column are zero when the value comes from the defaults.
+type ValidationProblem struct {
+	Path    string
+	Line    int
+	Column  int
+	Message string
+}
+
+func (p ValidationProblem) Location(file string) string {
+	location := file
+	if p.Line > 0 {
+		location = fmt.Sprintf("%s:%d:%d", location, p.Line, p.Column)
+	}
+	if location == "" {
+		return p.Path
+	}
+
+	return location + ": " + p.Path
+}
+
+// ValidationError holds every problem found while validating a config, rather
+// than only the first one.
+type ValidationError struct {
+	File     string
+	Problems []ValidationProblem
+}
+
+func (e ValidationError) Error() string {
+	s := strings.Builder{}
+	s.WriteString(fmt.Sprintf("%d problem(s) found in config", len(e.Problems)))
+	if e.File != "" {
+		s.WriteString(" " + e.File)
+	}
+	for _, problem := range e.Problems {
+		s.WriteString(fmt.Sprintf("\n  %s: %s", problem.Location(e.File), problem.Message))
+	}
+
+	return s.String()
+}
+
+type validator struct {
+	root     *yaml.Node
+	problems []ValidationProblem
+}
+
+func (p ConfigParser) validate(config Config, root *yaml.Node) []ValidationProblem {
+	v := validator{root: root}
+	if root != nil && len(root.Content) > 0 {
+		v.checkUnknownKeys(root.Content[0], reflect.TypeOf(config), nil)
+	}
+
+	v.checkSources(config.Sources)
+	v.checkSections(config.PlaceholderSections, "placeholderSections", config.Sources)
+	v.checkSections(config.OtherSections, "otherSections", config.Sources)
+	if len(config.PlaceholderSections) == 0 && len(config.OtherSections) == 0 {
+		v.addProblem([]interface{}{"placeholderSections"}, "at least one section is required in placeholderSections or otherSections")
+	}
+
+	if width := config.Defaults.Preview.Width; width != nil && (*width < 0 || *width > 100) {
+		v.addProblem(
+			[]interface{}{"defaults", "preview", "width"},
+			"must be between 0 and 100, got %d",
+			*width,
+		)
+	}
+
+	v.checkRefreshInterval(config.Defaults.RefreshInterval, []interface{}{"defaults", "refreshInterval"})
+	v.checkRetry(config.Retry)
+	keys := v.checkKeybindings(config)
+	v.checkActions(config.PlaceholderSections, "placeholderSections", keys)
+	v.checkActions(config.OtherSections, "otherSections", keys)
+
+	if config.Cache.MaxSizeMB < 0 {
+		v.addProblem([]interface{}{"cache", "maxSizeMB"}, "must not be negative, got %d", config.Cache.MaxSizeMB)
+	}
+
+	if !isKnownView(config.Defaults.View) {
+		v.addProblem(
+			[]interface{}{"defaults", "view"},
+			"unknown view %q, expected one of %s",
+			config.Defaults.View,
+			joinViews(knownViews),
+		)
+	}
+
+	return v.problems
+}
+
+func (v *validator) checkSources(sources map[string]SourceConfig) {
+	names := make([]string, 0, len(sources))
+	for name := range sources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		source := sources[name]
+		path := []interface{}{"sources", name}
+		if source.BaseURL == "" {
+			v.addProblem(append(path, "baseURL"), "must not be empty")
+		} else if u, err := url.Parse(source.BaseURL); err != nil || u.Scheme == "" || u.Host == "" {
+			v.addProblem(append(path, "baseURL"), "must be an absolute URL, got %q", source.BaseURL)
+		}
+
+		authPath := append(path, "auth")
+		if source.Auth.Bearer != nil && source.Auth.Basic != nil {
+			v.addProblem(authPath, "must not set both bearer and basic")
+		}
+		if source.Auth.Bearer != nil {
+			v.checkSecret(*source.Auth.Bearer, append(authPath, "bearer"))
+		}
+		if source.Auth.Basic != nil {
+			if source.Auth.Basic.Username == "" {
+				v.addProblem(append(authPath, "basic", "username"), "must not be empty")
+			}
+			v.checkSecret(source.Auth.Basic.Password, append(authPath, "basic", "password"))
+		}
+	}
+}
+
+func (v *validator) checkSecret(secret SecretConfig, path []interface{}) {
+	if (secret.Env == "") == (secret.Command == "") {
+		v.addProblem(path, "must set exactly one of env or command")
+	}
+}
+
+func (v *validator) checkSections(sections []SectionConfig, key string, sources map[string]SourceConfig) {
+	seenTitles := map[string]int{}
+	for i, section := range sections {
+		if strings.TrimSpace(section.Title) == "" {
+			v.addProblem([]interface{}{key, i, "title"}, "must not be empty")
+		} else if first, ok := seenTitles[section.Title]; ok {
+			v.addProblem(
+				[]interface{}{key, i, "title"},
+				"duplicate title %q, already used by %s[%d]",
+				section.Title,
+				key,
+				first,
+			)
+		} else {
+			seenTitles[section.Title] = i
+		}
+
+		if section.Type != "" && !isKnownSectionType(section.Type) {
+			v.addProblem(
+				[]interface{}{key, i, "type"},
+				"unknown section type %q, expected one of %s",
+				section.Type,
+				joinSectionTypes(knownSectionTypes),
+			)
+		}
+
+		if _, query, offset := splitFilters(section.Filters); query != "" {
+			if _, err := filter.Parse(query); err != nil {
+				var syntaxErr filter.SyntaxError
+				if errors.As(err, &syntaxErr) {
+					syntaxErr.Offset += offset
+					err = syntaxErr
+				}
+				v.addProblem([]interface{}{key, i, "filters"}, "invalid query at %v", err)
+			}
+		}
+
+		if section.Limit != nil && *section.Limit < 0 {
+			v.addProblem([]interface{}{key, i, "limit"}, "must not be negative, got %d", *section.Limit)
+		}
+
+		if _, ok := sources[section.GetSource()]; !ok {
+			v.addProblem([]interface{}{key, i, "source"}, "unknown source %q", section.GetSource())
+		}
+
+		if section.Pagination != "" && !isKnownPagination(section.Pagination) {
+			v.addProblem(
+				[]interface{}{key, i, "pagination"},
+				"unknown pagination %q, expected one of %s",
+				section.Pagination,
+				joinPaginations(knownPaginations),
+			)
+		}
+
+		if section.URLTemplate != "" {
+			if _, err := template.New("url").Parse(section.URLTemplate); err != nil {
+				v.addProblem([]interface{}{key, i, "urlTemplate"}, "invalid template: %v", err)
+			}
+		}
+
+		if section.TTL < 0 {
+			v.addProblem([]interface{}{key, i, "ttl"}, "must not be negative, got %s", section.TTL)
+		}
+		if section.RefreshInterval != nil {
+			v.checkRefreshInterval(*section.RefreshInterval, []interface{}{key, i, "refreshInterval"})
+		}
+
+		v.checkColumns(section, []interface{}{key, i})
+		v.checkSort(section, []interface{}{key, i, "sort"})
+	}
+}
+
+func (v *validator) checkColumns(section SectionConfig, path []interface{}) {
+	if section.Type != RESTSection {
+		if section.ItemsPath != "" {
+			v.addProblem(append(path, "itemsPath"), "is only used by %s sections", RESTSection)
+		}
+		if len(section.Columns) > 0 {
+			v.addProblem(append(path, "columns"), "are only used by %s sections", RESTSection)
+		}
+		return
+	}
+
+	if len(section.Columns) == 0 {
+		v.addProblem(append(path, "columns"), "must list at least one column")
+	}
+	for j, column := range section.Columns {
+		columnPath := append(append([]interface{}{}, path...), "columns", j)
+		if strings.TrimSpace(column.Title) == "" {
+			v.addProblem(append(columnPath, "title"), "must not be empty")
+		}
+		if (column.Field == "") == (column.Template == "") {
+			v.addProblem(columnPath, "must set exactly one of field or template")
+		}
+		if column.Template != "" {
+			if _, err := template.New("column").Parse(column.Template); err != nil {
+				v.addProblem(append(columnPath, "template"), "invalid template: %v", err)
+			}
+		}
+		if column.Width != nil && *column.Width < 1 {
+			v.addProblem(append(columnPath, "width"), "must be at least 1, got %d", *column.Width)
+		}
+	}
+}
+
+func (v *validator) checkSort(section SectionConfig, path []interface{}) {
+	if section.Sort == "" {
+		return
+	}
+	column, _ := section.GetSort()
+	if column == "" {
+		v.addProblem(path, "must name a column, got %q", section.Sort)
+		return
+	}
+
+	// The columns of other section types are only known to the app.
+	if section.Type != RESTSection || len(section.Columns) == 0 {
+		return
+	}
+	titles := make([]string, 0, len(section.Columns))
+	for _, c := range section.Columns {
+		if strings.EqualFold(c.Title, column) {
+			return
+		}
+		titles = append(titles, c.Title)
+	}
+	v.addProblem(path, "unknown column %q, expected one of %s", column, strings.Join(titles, ", "))
+}
+
+// checkKeybindings reports the problems of the keybindings and returns the
+// keys they bind.
+func (v *validator) checkKeybindings(config Config) pkg.KeyMap {
+	names := make([]string, 0, len(config.Keybindings))
+	for name := range config.Keybindings {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	actions := pkg.ActionNames()
+	valid := map[string][]string{}
+	for _, name := range names {
+		path := []interface{}{"keybindings", name}
+		if !containsString(actions, name) {
+			message := fmt.Sprintf("unknown action %q", name)
+			if suggestion := suggestName(name, actions); suggestion != "" {
+				message += fmt.Sprintf(", did you mean %q?", suggestion)
+			}
+			v.addProblem(path, "%s", message)
+			continue
+		}
+
+		keys := config.Keybindings[name]
+		if len(keys) == 0 {
+			v.addProblem(path, "must list at least one key")
+			continue
+		}
+		for i, boundKey := range keys {
+			if strings.TrimSpace(boundKey) == "" {
+				v.addProblem(append(path, i), "must not be empty")
+			}
+		}
+		valid[name] = keys
+	}
+
+	keys := pkg.Keys
+	if err := keys.Remap(valid); err != nil {
+		return keys
+	}
+	for _, conflict := range keys.Conflicts() {
+		// Point at a remapped action, the defaults do not conflict.
+		path := []interface{}{"keybindings"}
+		for _, action := range conflict.Actions {
+			if _, ok := valid[action]; ok {
+				path = append(path, action)
+				break
+			}
+		}
+		v.addProblem(path, "%v", conflict)
+	}
+
+	return keys
+}
+
+func (v *validator) checkActions(sections []SectionConfig, key string, keys pkg.KeyMap) {
+	names := pkg.ActionNames()
+	bindings := keys.Bindings()
+	for i, section := range sections {
+		seenKeys := map[string]int{}
+		for j, action := range section.Actions {
+			path := []interface{}{key, i, "actions", j}
+			if action.Key == "" {
+				v.addProblem(append(path, "key"), "must not be empty")
+			} else if first, ok := seenKeys[action.Key]; ok {
+				v.addProblem(append(path, "key"), "key %q is already used by action %d", action.Key, first)
+			} else {
+				seenKeys[action.Key] = j
+				for _, name := range names {
+					if containsString(bindings[name].Keys(), action.Key) {
+						v.addProblem(append(path, "key"), "key %q is already bound to %s", action.Key, name)
+					}
+				}
+			}
+
+			if strings.TrimSpace(action.Command) == "" {
+				v.addProblem(append(path, "command"), "must not be empty")
+			} else if _, err := parseCommand(action.Command); err != nil {
+				v.addProblem(append(path, "command"), "invalid template: %v", err)
+			}
+		}
+	}
+}
+
+// checkRefreshInterval rejects intervals short enough to flood the server.
+// Zero turns refreshing off.
+func (v *validator) checkRefreshInterval(interval time.Duration, path []interface{}) {
+	if interval < 0 {
+		v.addProblem(path, "must not be negative, got %s", interval)
+	} else if interval > 0 && interval < minRefreshInterval {
+		v.addProblem(path, "must be at least %s or 0 to turn it off, got %s", minRefreshInterval, interval)
+	}
+}
+
+func (v *validator) checkRetry(retry RetryConfig) {
+	if retry.MaxAttempts < 1 {
+		v.addProblem([]interface{}{"retry", "maxAttempts"}, "must be at least 1, got %d", retry.MaxAttempts)
+	}
+	if retry.BaseDelay < 0 {
+		v.addProblem([]interface{}{"retry", "baseDelay"}, "must not be negative, got %s", retry.BaseDelay)
+	}
+	if retry.MaxDelay < retry.BaseDelay {
+		v.addProblem(
+			[]interface{}{"retry", "maxDelay"},
+			"must not be smaller than baseDelay (%s), got %s",
+			retry.BaseDelay,
+			retry.MaxDelay,
+		)
+	}
+	for i, status := range retry.RetryableStatuses {
+		if status < 100 || status > 599 {
+			v.addProblem([]interface{}{"retry", "retryableStatuses", i}, "%d is not an HTTP status code", status)
+		}
+	}
+}
+
+// checkUnknownKeys walks the yaml tree alongside the Go type it decodes into
+// and reports every mapping key that has no matching field.
+func (v *validator) checkUnknownKeys(node *yaml.Node, t reflect.Type, path []interface{}) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch {
+	case t.Kind() == reflect.Struct && node.Kind == yaml.MappingNode:
+		fields := yamlFields(t)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			keyNode, valueNode := node.Content[i], node.Content[i+1]
+			fieldPath := append(append([]interface{}{}, path...), keyNode.Value)
+			field, ok := fields[keyNode.Value]
+			if !ok {
+				message := fmt.Sprintf("unknown key %q", keyNode.Value)
+				if suggestion := suggestKey(keyNode.Value, fields); suggestion != "" {
+					message += fmt.Sprintf(", did you mean %q?", suggestion)
+				}
+				v.problems = append(v.problems, ValidationProblem{
+					Path:    formatPath(fieldPath),
+					Line:    keyNode.Line,
+					Column:  keyNode.Column,
+					Message: message,
+				})
+				continue
+			}
+			v.checkUnknownKeys(valueNode, field.Type, fieldPath)
+		}
+	case t.Kind() == reflect.Map && node.Kind == yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			v.checkUnknownKeys(node.Content[i+1], t.Elem(), append(append([]interface{}{}, path...), node.Content[i].Value))
+		}
+	case t.Kind() == reflect.Slice && node.Kind == yaml.SequenceNode:
+		for i, item := range node.Content {
+			v.checkUnknownKeys(item, t.Elem(), append(append([]interface{}{}, path...), i))
+		}
+	}
+}
+
+func (v *validator) addProblem(path []interface{}, format string, args ...interface{}) {
+	problem := ValidationProblem{
+		Path:    formatPath(path),
+		Message: fmt.Sprintf(format, args...),
+	}
+	if node := findNode(v.root, path); node != nil {
+		problem.Line = node.Line
+		problem.Column = node.Column
+	}
+
+	v.problems = append(v.problems, problem)
+}
+
+// findNode returns the value node at path, where each element is either a
+// mapping key or a sequence index.
+func findNode(root *yaml.Node, path []interface{}) *yaml.Node {
+	if root == nil || len(root.Content) == 0 {
+		return nil
+	}
+
+	node := root.Content[0]
+	for _, element := range path {
+		switch element := element.(type) {
+		case string:
+			if node.Kind != yaml.MappingNode {
+				return nil
+			}
+			var next *yaml.Node
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				if node.Content[i].Value == element {
+					next = node.Content[i+1]
+				}
+			}
+			if next == nil {
+				return nil
+			}
+			node = next
+		case int:
+			if node.Kind != yaml.SequenceNode || element >= len(node.Content) {
+				return nil
+			}
+			node = node.Content[element]
+		}
+	}
+
+	return node
+}
+
+func yamlFields(t reflect.Type) map[string]reflect.StructField {
+	fields := make(map[string]reflect.StructField, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+		name := strings.Split(field.Tag.Get("yaml"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+		fields[name] = field
+	}
+
+	return fields
+}
+
+func suggestKey(key string, fields map[string]reflect.StructField) string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return suggestName(key, names)
+}
+
+// suggestName returns the name closest to the misspelled one, or an empty
+// string when none is close enough.
+func suggestName(misspelled string, names []string) string {
+	best, bestDistance := "", 3
+	for _, name := range names {
+		if strings.EqualFold(name, misspelled) {
+			return name
+		}
+		if distance := levenshtein(strings.ToLower(misspelled), strings.ToLower(name)); distance < bestDistance {
+			best, bestDistance = name, distance
+		}
+	}
+
+	return best
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func levenshtein(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr := make([]int, len(b)+1)
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = pkg.Min(prev[j]+1, pkg.Min(curr[j-1]+1, prev[j-1]+cost))
+		}
+		prev = curr
+	}
+
+	return prev[len(b)]
+}
+
+func formatPath(path []interface{}) string {
+	s := strings.Builder{}
+	for _, element := range path {
+		switch element := element.(type) {
+		case int:
+			s.WriteString(fmt.Sprintf("[%d]", element))
+		default:
+			if s.Len() > 0 {
+				s.WriteString(".")
+			}
+			s.WriteString(fmt.Sprint(element))
+		}
+	}
+
+	return s.String()
+}
+
+func isKnownView(view ViewType) bool {
+	for _, knownView := range knownViews {
+		if view == knownView {
+			return true
+		}
+	}
+	return false
+}
+
+func joinViews(views []ViewType) string {
+	names := make([]string, 0, len(views))
+	for _, view := range views {
+		names = append(names, string(view))
+	}
+	return strings.Join(names, ", ")
+}
+
+func isKnownSectionType(sectionType SectionType) bool {
+	for _, knownType := range knownSectionTypes {
+		if sectionType == knownType {
+			return true
+		}
+	}
+	return false
+}
+
+func joinSectionTypes(types []SectionType) string {
+	names := make([]string, 0, len(types))
+	for _, sectionType := range types {
+		names = append(names, string(sectionType))
+	}
+	return strings.Join(names, ", ")
+}
+
+func isKnownPagination(pagination PaginationType) bool {
+	for _, knownPagination := range knownPaginations {
+		if pagination == knownPagination {
+			return true
+		}
+	}
+	return false
+}
+
+func joinPaginations(paginations []PaginationType) string {
+	names := make([]string, 0, len(paginations))
+	for _, pagination := range paginations {
+		names = append(names, string(pagination))
+	}
+	return strings.Join(names, ", ")
+}
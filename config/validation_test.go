@@ -0,0 +1,162 @@
+package config
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestValidateUnknownKeys(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want []ValidationProblem
+	}{
+		{
+			name: "misspelled top level key",
+			data: `placeholderSections:
+  - title: Posts
+    filters: posts
+defautls:
+  view: placeholder
+`,
+			want: []ValidationProblem{
+				{Path: "defautls", Line: 4, Column: 1, Message: `unknown key "defautls", did you mean "defaults"?`},
+			},
+		},
+		{
+			name: "misspelled section keys",
+			data: `placeholderSections:
+  - title: Posts
+    filter: posts
+    limt: 3
+`,
+			want: []ValidationProblem{
+				{Path: "placeholderSections[0].filter", Line: 3, Column: 5, Message: `unknown key "filter", did you mean "filters"?`},
+				{Path: "placeholderSections[0].limt", Line: 4, Column: 5, Message: `unknown key "limt", did you mean "limit"?`},
+			},
+		},
+		{
+			name: "different case",
+			data: `placeholderSections:
+  - title: Posts
+    filters: posts
+    urltemplate: https://medium.com
+`,
+			want: []ValidationProblem{
+				{Path: "placeholderSections[0].urltemplate", Line: 4, Column: 5, Message: `unknown key "urltemplate", did you mean "urlTemplate"?`},
+			},
+		},
+		{
+			name: "nested key",
+			data: `placeholderSections:
+  - title: Posts
+    filters: posts
+defaults:
+  preview:
+    widht: 40
+`,
+			want: []ValidationProblem{
+				{Path: "defaults.preview.widht", Line: 6, Column: 5, Message: `unknown key "widht", did you mean "width"?`},
+			},
+		},
+		{
+			name: "key in a map value",
+			data: `placeholderSections:
+  - title: Posts
+    filters: posts
+sources:
+  api:
+    baseUrl: https://api.example.com
+`,
+			want: []ValidationProblem{
+				{Path: "sources.api.baseUrl", Line: 6, Column: 5, Message: `unknown key "baseUrl", did you mean "baseURL"?`},
+				{Path: "sources.api.baseURL", Line: 0, Column: 0, Message: "must not be empty"},
+			},
+		},
+		{
+			name: "nothing close enough",
+			data: `placeholderSections:
+  - title: Posts
+    filters: posts
+    colour: red
+`,
+			want: []ValidationProblem{
+				{Path: "placeholderSections[0].colour", Line: 4, Column: 5, Message: `unknown key "colour"`},
+			},
+		},
+		{
+			name: "misspelled action",
+			data: `placeholderSections:
+  - title: Posts
+    filters: posts
+keybindings:
+  qiut: [x]
+`,
+			want: []ValidationProblem{
+				{Path: "keybindings.qiut", Line: 5, Column: 9, Message: `unknown action "qiut", did you mean "quit"?`},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path := writeConfig(t, test.data)
+			_, err := ParseConfig(path)
+			var validationErr ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("ParseConfig() error = %v, want a ValidationError", err)
+			}
+			if validationErr.File != path {
+				t.Errorf("ValidationError.File = %q, want %q", validationErr.File, path)
+			}
+			if !reflect.DeepEqual(validationErr.Problems, test.want) {
+				t.Errorf("problems = %+v, want %+v", validationErr.Problems, test.want)
+			}
+		})
+	}
+}
+
+func TestValidateKnownKeys(t *testing.T) {
+	path := writeConfig(t, `placeholderSections:
+  - title: Posts
+    filters: posts completed:false
+    limit: 10
+    pagination: page
+    urlTemplate: https://medium.com/p/{{.Id}}
+defaults:
+  view: placeholder
+  preview:
+    open: true
+    width: 40
+keybindings:
+  quit: [x]
+`)
+	if _, err := ParseConfig(path); err != nil {
+		t.Errorf("ParseConfig() error = %v", err)
+	}
+}
+
+func TestSuggestName(t *testing.T) {
+	names := []string{"defaults", "filters", "limit", "title"}
+	tests := []struct {
+		misspelled string
+		want       string
+	}{
+		{misspelled: "filter", want: "filters"},
+		{misspelled: "FILTERS", want: "filters"},
+		{misspelled: "titel", want: "title"},
+		{misspelled: "lmit", want: "limit"},
+		{misspelled: "defautls", want: "defaults"},
+		{misspelled: "colour", want: ""},
+		{misspelled: "", want: ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.misspelled, func(t *testing.T) {
+			if got := suggestName(test.misspelled, names); got != test.want {
+				t.Errorf("suggestName(%q) = %q, want %q", test.misspelled, got, test.want)
+			}
+		})
+	}
+}
@@ -7,21 +7,43 @@ import (
 	"os"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mehmetcantas/medium-cli/config"
+	"github.com/mehmetcantas/medium-cli/pkg"
 	"github.com/mehmetcantas/medium-cli/ui"
 )
 
-func createModel(debug bool) (ui.Model, *os.File) {
-	var loggerFile *os.File
-	var err error
+func createModel(options ui.Options, debug bool) (ui.Model, *os.File) {
+	return ui.NewModel(options), openLogger(debug)
+}
+
+// openLogger sends the log to debug.log when debug is set.
+func openLogger(debug bool) *os.File {
+	if !debug {
+		return nil
+	}
+
+	loggerFile, err := tea.LogToFile("debug.log", "debug")
+	if err != nil {
+		fmt.Println("Error setting up logger")
+	}
+	return loggerFile
+}
+
+// runConfigCommand handles `medium-cli config <subcommand>` and returns the
+// process exit code.
+func runConfigCommand(configPath string, args []string) int {
+	if len(args) != 1 || args[0] != "validate" {
+		fmt.Fprintln(os.Stderr, "usage: medium-cli [--config path] config validate")
+		return 2
+	}
 
-	if debug {
-		loggerFile, err = tea.LogToFile("debug.log", "debug")
-		if err != nil {
-			fmt.Println("Error setting up logger")
-		}
+	if _, err := config.ParseConfig(configPath); err != nil {
+		printError(err)
+		return 1
 	}
 
-	return ui.NewModel(), loggerFile
+	fmt.Println("config is valid")
+	return 0
 }
 
 func main() {
@@ -30,8 +52,32 @@ func main() {
 		true,
 		"passing this flag will allow writing debug output to debug.log",
 	)
+	configPath := flag.String(
+		"config",
+		"",
+		"use this configuration file (default lookup: $XDG_CONFIG_HOME/medium-cli/config.yml, then ~/.config/medium-cli/config.yml)",
+	)
+	noCache := flag.Bool(
+		"no-cache",
+		false,
+		"passing this flag will always fetch fresh data and never read or write the response cache",
+	)
+	offline := flag.Bool(
+		"offline",
+		false,
+		"passing this flag will never contact the server and show the cached data only",
+	)
 	flag.Parse()
-	model, logger := createModel(*debug)
+
+	options := ui.Options{ConfigPath: *configPath, NoCache: *noCache, Offline: *offline}
+	switch flag.Arg(0) {
+	case "config":
+		os.Exit(runConfigCommand(*configPath, flag.Args()[1:]))
+	case "list", "get":
+		os.Exit(runCommand(flag.Arg(0), options, flag.Args()[1:], *debug))
+	}
+
+	model, logger := createModel(options, *debug)
 	if logger != nil {
 		defer logger.Close()
 	}
@@ -40,7 +86,11 @@ func main() {
 		model,
 		tea.WithAltScreen(),
 	)
-	if err := p.Start(); err != nil {
+	// Background refreshes pause while the terminal is not focused.
+	fmt.Print(pkg.EnableFocusReporting)
+	err := p.Start()
+	fmt.Print(pkg.DisableFocusReporting)
+	if err != nil {
 		log.Fatal(err)
 	}
 }
@@ -0,0 +1,83 @@
+package pkg
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// Launcher opens a URL outside of the terminal. It is a variable of the UI so
+// tests and headless setups can replace it.
+type Launcher func(url string) error
+
+// startCommand starts a command without waiting for it to exit, since
+// browsers may keep running until they are closed. It is replaced in tests.
+var startCommand = func(name string, args ...string) error {
+	cmd := exec.Command(name, args...)
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	go func() {
+		if err := cmd.Wait(); err != nil {
+			log.Printf("%s exited with an error: %v\n", name, err)
+		}
+	}()
+	return nil
+}
+
+// OpenInBrowser opens url with the commands of $BROWSER, a colon separated
+// list where %s stands for the URL, and falls back to the opener of the
+// platform, e.g. xdg-open. It returns once the first command that could be
+// found is started.
+func OpenInBrowser(url string) error {
+	var errs []string
+	for _, command := range browserCommands(url) {
+		err := startCommand(command[0], command[1:]...)
+		if err == nil {
+			return nil
+		}
+		errs = append(errs, fmt.Sprintf("%s: %v", command[0], err))
+	}
+
+	if len(errs) == 0 {
+		return errors.New("no browser command found, set $BROWSER")
+	}
+	return errors.New(strings.Join(errs, ", "))
+}
+
+func browserCommands(url string) [][]string {
+	var commands [][]string
+	for _, browser := range strings.Split(os.Getenv("BROWSER"), ":") {
+		args := strings.Fields(browser)
+		if len(args) == 0 {
+			continue
+		}
+
+		hasPlaceholder := false
+		for i, arg := range args {
+			if strings.Contains(arg, "%s") {
+				args[i] = strings.ReplaceAll(arg, "%s", url)
+				hasPlaceholder = true
+			}
+		}
+		if !hasPlaceholder {
+			args = append(args, url)
+		}
+		commands = append(commands, args)
+	}
+
+	switch runtime.GOOS {
+	case "darwin":
+		commands = append(commands, []string{"open", url})
+	case "windows":
+		commands = append(commands, []string{"rundll32", "url.dll,FileProtocolHandler", url})
+	default:
+		commands = append(commands, []string{"xdg-open", url})
+	}
+
+	return commands
+}
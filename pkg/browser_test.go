@@ -0,0 +1,105 @@
+package pkg
+
+import (
+	"errors"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestOpenInBrowser(t *testing.T) {
+	const url = "https://example.com/posts/1"
+	opener := "xdg-open"
+	switch runtime.GOOS {
+	case "darwin":
+		opener = "open"
+	case "windows":
+		opener = "rundll32"
+	}
+
+	tests := []struct {
+		name    string
+		browser string
+		// missing are the commands that cannot be started.
+		missing     []string
+		wantStarted [][]string
+		wantErr     bool
+	}{
+		{
+			name:        "platform opener",
+			browser:     "",
+			wantStarted: [][]string{{opener}},
+		},
+		{
+			name:        "browser with placeholder",
+			browser:     "firefox --new-tab %s",
+			wantStarted: [][]string{{"firefox", "--new-tab", url}},
+		},
+		{
+			name:        "browser without placeholder",
+			browser:     "lynx",
+			wantStarted: [][]string{{"lynx", url}},
+		},
+		{
+			name:        "falls back to the next browser",
+			browser:     "chromium:w3m",
+			missing:     []string{"chromium"},
+			wantStarted: [][]string{{"w3m", url}},
+		},
+		{
+			name:        "falls back to the platform opener",
+			browser:     "chromium",
+			missing:     []string{"chromium"},
+			wantStarted: [][]string{{opener}},
+		},
+		{
+			name:    "nothing found",
+			browser: "chromium",
+			missing: []string{"chromium", opener},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Setenv("BROWSER", test.browser)
+			var started [][]string
+			restore := startCommand
+			defer func() { startCommand = restore }()
+			startCommand = func(name string, args ...string) error {
+				for _, missing := range test.missing {
+					if name == missing {
+						return errors.New("executable file not found")
+					}
+				}
+				started = append(started, append([]string{name}, args...))
+				return nil
+			}
+
+			err := OpenInBrowser(url)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("OpenInBrowser() error = %v, want error %v", err, test.wantErr)
+			}
+			if test.wantErr {
+				for _, missing := range test.missing {
+					if !strings.Contains(err.Error(), missing) {
+						t.Errorf("OpenInBrowser() error = %v, want it to mention %s", err, missing)
+					}
+				}
+				return
+			}
+
+			// The platform openers only get the URL as their last argument.
+			if len(started) == 1 && started[0][0] == opener && test.wantStarted[0][0] == opener {
+				if started[0][len(started[0])-1] != url {
+					t.Errorf("started %v, want the URL last", started[0])
+				}
+				return
+			}
+			if !reflect.DeepEqual(started, test.wantStarted) {
+				t.Errorf("started %v, want %v", started, test.wantStarted)
+			}
+		})
+	}
+}
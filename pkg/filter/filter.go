@@ -0,0 +1,234 @@
+// Package filter implements the query language of section filters, matched
+// against the rows loaded from the server, e.g.
+//
+//	userId:1 -completed:true
+//	(title:"et ea" OR id>=90) voluptate
+//
+// Terms are field:value, field>value, field>=value, field<value and
+// field<=value, negated with a leading -. Words and quoted phrases without a
+// field match the title of the row. Terms next to each other must all match,
+// unless joined with OR, and parentheses group them.
+package filter
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mehmetcantas/medium-cli/pkg"
+)
+
+// titleFields are the fields free text is matched against, the first one the
+// row has.
+var titleFields = []string{"title", "name"}
+
+// dateLayouts are the formats of the values compared as dates.
+var dateLayouts = []string{time.RFC3339, "2006-01-02"}
+
+// Query is a parsed query. A nil query matches every record.
+type Query struct {
+	root node
+	text string
+}
+
+func (q *Query) String() string {
+	if q == nil {
+		return ""
+	}
+	return q.text
+}
+
+// Match reports whether record matches the query. Fields are looked up by
+// their JSON names, with dots reaching into nested objects, e.g.
+// company.name.
+func (q *Query) Match(record interface{}) bool {
+	if q == nil {
+		return true
+	}
+	return q.root.match(newRow(record))
+}
+
+type node interface {
+	match(r row) bool
+}
+
+type andNode struct {
+	left, right node
+}
+
+func (n andNode) match(r row) bool {
+	return n.left.match(r) && n.right.match(r)
+}
+
+type orNode struct {
+	left, right node
+}
+
+func (n orNode) match(r row) bool {
+	return n.left.match(r) || n.right.match(r)
+}
+
+type notNode struct {
+	operand node
+}
+
+func (n notNode) match(r row) bool {
+	return !n.operand.match(r)
+}
+
+type textNode struct {
+	text string
+}
+
+func (n textNode) match(r row) bool {
+	return containsFold(r.title(), n.text)
+}
+
+type fieldNode struct {
+	field string
+	op    string
+	value string
+}
+
+func (n fieldNode) match(r row) bool {
+	value, ok := pkg.LookupPath(r.document, n.field)
+	if !ok {
+		return false
+	}
+	return n.matchValue(value)
+}
+
+// matchValue compares value to the term's value. Arrays match when one of
+// their items does.
+func (n fieldNode) matchValue(value interface{}) bool {
+	if items, ok := value.([]interface{}); ok {
+		for _, item := range items {
+			if n.matchValue(item) {
+				return true
+			}
+		}
+		return false
+	}
+
+	actual := formatValue(value)
+	if n.op == ":" {
+		if a, b, ok := parseNumbers(actual, n.value); ok {
+			return a == b
+		}
+		return containsFold(actual, n.value)
+	}
+
+	comparison := compare(actual, n.value)
+	switch n.op {
+	case ">":
+		return comparison > 0
+	case ">=":
+		return comparison >= 0
+	case "<":
+		return comparison < 0
+	default:
+		return comparison <= 0
+	}
+}
+
+// row is a record decoded as JSON, so that fields are found by the names the
+// server uses whatever the Go type of the record.
+type row struct {
+	document interface{}
+}
+
+func newRow(record interface{}) row {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return row{}
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var document interface{}
+	if err := decoder.Decode(&document); err != nil {
+		return row{}
+	}
+	return row{document: document}
+}
+
+func (r row) title() string {
+	for _, field := range titleFields {
+		if value, ok := pkg.LookupPath(r.document, field); ok {
+			return formatValue(value)
+		}
+	}
+	return ""
+}
+
+// compare orders numbers and dates by value and anything else as text,
+// ignoring case.
+func compare(a, b string) int {
+	if x, y, ok := parseNumbers(a, b); ok {
+		switch {
+		case x < y:
+			return -1
+		case x > y:
+			return 1
+		default:
+			return 0
+		}
+	}
+	if x, y, ok := parseDates(a, b); ok {
+		switch {
+		case x.Before(y):
+			return -1
+		case x.After(y):
+			return 1
+		default:
+			return 0
+		}
+	}
+	return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+}
+
+func parseNumbers(a, b string) (float64, float64, bool) {
+	x, errA := strconv.ParseFloat(a, 64)
+	y, errB := strconv.ParseFloat(b, 64)
+	return x, y, errA == nil && errB == nil
+}
+
+func parseDates(a, b string) (time.Time, time.Time, bool) {
+	x, okA := parseDate(a)
+	y, okB := parseDate(b)
+	return x, y, okA && okB
+}
+
+func parseDate(s string) (time.Time, bool) {
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func formatValue(value interface{}) string {
+	switch value := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case json.Number:
+		return value.String()
+	case bool:
+		return strconv.FormatBool(value)
+	default:
+		data, err := json.Marshal(value)
+		if err != nil {
+			return ""
+		}
+		return string(data)
+	}
+}
+
+func containsFold(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
@@ -0,0 +1,120 @@
+package filter
+
+import (
+	"testing"
+	"time"
+)
+
+type testCompany struct {
+	Name string `json:"name"`
+}
+
+type testRecord struct {
+	Id        int         `json:"id"`
+	Title     string      `json:"title"`
+	Completed bool        `json:"completed"`
+	Tags      []string    `json:"tags"`
+	Published time.Time   `json:"published"`
+	Company   testCompany `json:"company"`
+}
+
+func TestMatch(t *testing.T) {
+	record := testRecord{
+		Id:        42,
+		Title:     "Et ea voluptate",
+		Completed: true,
+		Tags:      []string{"golang", "terminal"},
+		Published: time.Date(2023, 1, 2, 15, 4, 5, 0, time.UTC),
+		Company:   testCompany{Name: "Romaguera-Crona"},
+	}
+	tests := []struct {
+		query string
+		want  bool
+	}{
+		{query: "voluptate", want: true},
+		{query: "VOLUPTATE", want: true},
+		{query: "dolor", want: false},
+		{query: `"et ea"`, want: true},
+		{query: `"ea et"`, want: false},
+		{query: "id:42", want: true},
+		{query: "id:4", want: false},
+		{query: "id>41", want: true},
+		{query: "id>42", want: false},
+		{query: "id>=42", want: true},
+		{query: "id<100", want: true},
+		{query: "id<=41", want: false},
+		// Numbers compare by value, not as text.
+		{query: "id>9", want: true},
+		{query: "completed:true", want: true},
+		{query: "-completed:true", want: false},
+		{query: "tags:term", want: true},
+		{query: "tags:rust", want: false},
+		{query: "company.name:romaguera", want: true},
+		{query: "company.name:deckow", want: false},
+		{query: "published>=2023-01-01", want: true},
+		{query: "published<2023-01-02T15:04:05Z", want: false},
+		{query: "missing:1", want: false},
+		{query: "-missing:1", want: true},
+		{query: "voluptate id:1", want: false},
+		{query: "dolor OR id:42", want: true},
+		{query: "(dolor OR et) -completed:false", want: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.query, func(t *testing.T) {
+			query, err := Parse(test.query)
+			if err != nil {
+				t.Fatalf("Parse(%q) error = %v", test.query, err)
+			}
+			if got := query.Match(record); got != test.want {
+				t.Errorf("Parse(%q).Match() = %v, want %v", test.query, got, test.want)
+			}
+		})
+	}
+}
+
+func TestMatchMap(t *testing.T) {
+	record := map[string]interface{}{
+		"name":    "Leanne Graham",
+		"id":      1,
+		"address": map[string]interface{}{"city": "Gwenborough", "zipcode": "92998-3874"},
+		"roles":   []interface{}{"admin", "editor"},
+		"score":   9.5,
+	}
+	tests := []struct {
+		query string
+		want  bool
+	}{
+		// Free text falls back to the name of records without a title.
+		{query: "leanne", want: true},
+		{query: "ervin", want: false},
+		{query: "address.city:gwen", want: true},
+		{query: "address.zipcode:92998", want: true},
+		{query: "roles:admin", want: true},
+		{query: "-roles:admin", want: false},
+		{query: "score>9", want: true},
+		{query: "score>=10", want: false},
+		{query: "id:1 roles:editor", want: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.query, func(t *testing.T) {
+			query, err := Parse(test.query)
+			if err != nil {
+				t.Fatalf("Parse(%q) error = %v", test.query, err)
+			}
+			if got := query.Match(record); got != test.want {
+				t.Errorf("Parse(%q).Match() = %v, want %v", test.query, got, test.want)
+			}
+		})
+	}
+}
+
+func TestNilQueryMatchesEverything(t *testing.T) {
+	var query *Query
+	for _, record := range []interface{}{nil, testRecord{}, map[string]interface{}{}} {
+		if !query.Match(record) {
+			t.Errorf("nil query does not match %#v", record)
+		}
+	}
+}
@@ -0,0 +1,259 @@
+package filter
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// SyntaxError is a query that could not be parsed. Offset is the byte offset
+// of the problem in the query.
+type SyntaxError struct {
+	Offset  int
+	Message string
+}
+
+func (e SyntaxError) Error() string {
+	return fmt.Sprintf("column %d: %s", e.Offset+1, e.Message)
+}
+
+// parser is a recursive descent parser of the grammar
+//
+//	query   = or
+//	or      = and { "OR" and }
+//	and     = unary { [ "AND" ] unary }
+//	unary   = "-" unary | primary
+//	primary = "(" or ")" | field op value | value
+//	op      = ":" | ">" | ">=" | "<" | "<="
+//	value   = word | quoted phrase
+type parser struct {
+	input string
+	pos   int
+}
+
+// Parse parses a query. It returns a nil query, matching every record, when
+// input is blank.
+func Parse(input string) (*Query, error) {
+	p := parser{input: input}
+	p.skipSpace()
+	if p.eof() {
+		return nil, nil
+	}
+
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpace()
+	if !p.eof() {
+		// Only an unmatched ) stops parseOr before the end.
+		return nil, p.errorf(p.pos, "unexpected )")
+	}
+
+	return &Query{root: root, text: input}, nil
+}
+
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		p.skipSpace()
+		if !p.keyword("OR") {
+			return left, nil
+		}
+		start := p.pos
+		p.pos += len("OR")
+		p.skipSpace()
+		if p.eof() || p.peek() == ')' {
+			return nil, p.errorf(start, "expected a term after OR")
+		}
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orNode{left, right}
+	}
+}
+
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		p.skipSpace()
+		if p.eof() || p.peek() == ')' || p.keyword("OR") {
+			return left, nil
+		}
+		if p.keyword("AND") {
+			start := p.pos
+			p.pos += len("AND")
+			p.skipSpace()
+			if p.eof() || p.peek() == ')' || p.keyword("OR") {
+				return nil, p.errorf(start, "expected a term after AND")
+			}
+		}
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = andNode{left, right}
+	}
+}
+
+func (p *parser) parseUnary() (node, error) {
+	if p.peek() != '-' {
+		return p.parsePrimary()
+	}
+
+	start := p.pos
+	p.pos++
+	if p.eof() || isSpace(p.peek()) {
+		return nil, p.errorf(start, "expected a term right after -")
+	}
+	operand, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	return notNode{operand}, nil
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	start := p.pos
+	switch p.peek() {
+	case '(':
+		p.pos++
+		p.skipSpace()
+		if p.peek() == ')' {
+			return nil, p.errorf(start, "empty group")
+		}
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		p.skipSpace()
+		if p.peek() != ')' {
+			return nil, p.errorf(start, "missing ) to close this (")
+		}
+		p.pos++
+		return inner, nil
+	case ')':
+		return nil, p.errorf(start, "unexpected )")
+	case '"':
+		phrase, err := p.parseQuoted()
+		if err != nil {
+			return nil, err
+		}
+		return textNode{phrase}, nil
+	}
+
+	if p.keyword("AND") || p.keyword("OR") {
+		return nil, p.errorf(start, "expected a term before %s", p.readWord())
+	}
+
+	word := p.readWord()
+	op := p.readOperator()
+	if op == "" {
+		return textNode{word}, nil
+	}
+	if word == "" {
+		return nil, p.errorf(start, "expected a field name before %s", op)
+	}
+
+	var value string
+	if p.peek() == '"' {
+		var err error
+		if value, err = p.parseQuoted(); err != nil {
+			return nil, err
+		}
+	} else {
+		value = p.readValue()
+	}
+	if value == "" {
+		return nil, p.errorf(start, "expected a value after %s%s", word, op)
+	}
+
+	return fieldNode{field: word, op: op, value: value}, nil
+}
+
+func (p *parser) parseQuoted() (string, error) {
+	start := p.pos
+	end := strings.IndexByte(p.input[start+1:], '"')
+	if end < 0 {
+		return "", p.errorf(start, "unclosed quote")
+	}
+	p.pos = start + 1 + end + 1
+	return p.input[start+1 : start+1+end], nil
+}
+
+// readWord reads a field name or free text word.
+func (p *parser) readWord() string {
+	start := p.pos
+	for !p.eof() {
+		c := p.peek()
+		if isSpace(c) || strings.ContainsRune(`()":<>`, rune(c)) {
+			break
+		}
+		p.pos++
+	}
+	return p.input[start:p.pos]
+}
+
+// readValue reads the value of a field term, which may contain operator
+// characters, e.g. url:https://medium.com.
+func (p *parser) readValue() string {
+	start := p.pos
+	for !p.eof() && !isSpace(p.peek()) && p.peek() != ')' {
+		p.pos++
+	}
+	return p.input[start:p.pos]
+}
+
+func (p *parser) readOperator() string {
+	for _, op := range []string{">=", "<=", ":", ">", "<"} {
+		if strings.HasPrefix(p.input[p.pos:], op) {
+			p.pos += len(op)
+			return op
+		}
+	}
+	return ""
+}
+
+// keyword reports whether the input continues with the keyword as a whole
+// word.
+func (p *parser) keyword(keyword string) bool {
+	if !strings.HasPrefix(p.input[p.pos:], keyword) {
+		return false
+	}
+	end := p.pos + len(keyword)
+	return end == len(p.input) || isSpace(p.input[end]) || p.input[end] == '(' || p.input[end] == ')'
+}
+
+func (p *parser) skipSpace() {
+	for !p.eof() && isSpace(p.peek()) {
+		p.pos++
+	}
+}
+
+func (p *parser) peek() byte {
+	if p.eof() {
+		return 0
+	}
+	return p.input[p.pos]
+}
+
+func (p *parser) eof() bool {
+	return p.pos >= len(p.input)
+}
+
+func (p *parser) errorf(offset int, format string, args ...interface{}) error {
+	return SyntaxError{Offset: offset, Message: fmt.Sprintf(format, args...)}
+}
+
+func isSpace(c byte) bool {
+	return unicode.IsSpace(rune(c))
+}
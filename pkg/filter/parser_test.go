@@ -0,0 +1,143 @@
+package filter
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input string
+		want  node
+	}{
+		{input: "voluptate", want: textNode{"voluptate"}},
+		{input: `"et ea"`, want: textNode{"et ea"}},
+		{input: "userId:1", want: fieldNode{field: "userId", op: ":", value: "1"}},
+		{input: "id>90", want: fieldNode{field: "id", op: ">", value: "90"}},
+		{input: "id>=90", want: fieldNode{field: "id", op: ">=", value: "90"}},
+		{input: "id<90", want: fieldNode{field: "id", op: "<", value: "90"}},
+		{input: "id<=90", want: fieldNode{field: "id", op: "<=", value: "90"}},
+		{input: `title:"et ea"`, want: fieldNode{field: "title", op: ":", value: "et ea"}},
+		{input: "url:https://medium.com", want: fieldNode{field: "url", op: ":", value: "https://medium.com"}},
+		{input: "company.name:Romaguera", want: fieldNode{field: "company.name", op: ":", value: "Romaguera"}},
+		{input: "-completed:true", want: notNode{fieldNode{field: "completed", op: ":", value: "true"}}},
+		{input: "--a", want: notNode{notNode{textNode{"a"}}}},
+		{input: "  a  ", want: textNode{"a"}},
+		// OR and AND are keywords as whole words only.
+		{input: "ORDER ANDROID", want: andNode{textNode{"ORDER"}, textNode{"ANDROID"}}},
+		{input: "or", want: textNode{"or"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			query, err := Parse(test.input)
+			if err != nil {
+				t.Fatalf("Parse(%q) error = %v", test.input, err)
+			}
+			if !reflect.DeepEqual(query.root, test.want) {
+				t.Errorf("Parse(%q) = %#v, want %#v", test.input, query.root, test.want)
+			}
+			if query.String() != test.input {
+				t.Errorf("Parse(%q).String() = %q, want the input", test.input, query.String())
+			}
+		})
+	}
+}
+
+func TestParseBlank(t *testing.T) {
+	for _, input := range []string{"", "   ", "\t\n"} {
+		query, err := Parse(input)
+		if err != nil || query != nil {
+			t.Errorf("Parse(%q) = %v, %v, want a nil query", input, query, err)
+		}
+	}
+}
+
+func TestParsePrecedence(t *testing.T) {
+	a, b, c, d := textNode{"a"}, textNode{"b"}, textNode{"c"}, textNode{"d"}
+	tests := []struct {
+		input string
+		want  node
+	}{
+		{input: "a b", want: andNode{a, b}},
+		{input: "a AND b", want: andNode{a, b}},
+		{input: "a b c", want: andNode{andNode{a, b}, c}},
+		{input: "a OR b OR c", want: orNode{orNode{a, b}, c}},
+		// AND binds tighter than OR, on both sides.
+		{input: "a b OR c", want: orNode{andNode{a, b}, c}},
+		{input: "a OR b c", want: orNode{a, andNode{b, c}}},
+		{input: "a OR b AND c OR d", want: orNode{orNode{a, andNode{b, c}}, d}},
+		// Parentheses group.
+		{input: "a (b OR c)", want: andNode{a, orNode{b, c}}},
+		{input: "(a OR b) (c OR d)", want: andNode{orNode{a, b}, orNode{c, d}}},
+		{input: "((a))", want: a},
+		{input: "a OR(b c)", want: orNode{a, andNode{b, c}}},
+		// - applies to the term right after it.
+		{input: "-a b", want: andNode{notNode{a}, b}},
+		{input: "-(a OR b) c", want: andNode{notNode{orNode{a, b}}, c}},
+		{input: "-a OR b", want: orNode{notNode{a}, b}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			query, err := Parse(test.input)
+			if err != nil {
+				t.Fatalf("Parse(%q) error = %v", test.input, err)
+			}
+			if !reflect.DeepEqual(query.root, test.want) {
+				t.Errorf("Parse(%q) = %#v, want %#v", test.input, query.root, test.want)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		input       string
+		wantOffset  int
+		wantMessage string
+	}{
+		{input: "a OR", wantOffset: 2, wantMessage: "expected a term after OR"},
+		{input: "(a OR )", wantOffset: 3, wantMessage: "expected a term after OR"},
+		{input: "a AND", wantOffset: 2, wantMessage: "expected a term after AND"},
+		{input: "a AND OR b", wantOffset: 2, wantMessage: "expected a term after AND"},
+		{input: "OR a", wantOffset: 0, wantMessage: "expected a term before OR"},
+		{input: "a (AND b)", wantOffset: 3, wantMessage: "expected a term before AND"},
+		{input: "- a", wantOffset: 0, wantMessage: "expected a term right after -"},
+		{input: "a -", wantOffset: 2, wantMessage: "expected a term right after -"},
+		{input: "()", wantOffset: 0, wantMessage: "empty group"},
+		{input: "a ( )", wantOffset: 2, wantMessage: "empty group"},
+		{input: "(a b", wantOffset: 0, wantMessage: "missing ) to close this ("},
+		{input: "x (a (b OR c)", wantOffset: 2, wantMessage: "missing ) to close this ("},
+		{input: "a )", wantOffset: 2, wantMessage: "unexpected )"},
+		{input: ")", wantOffset: 0, wantMessage: "unexpected )"},
+		{input: `"et ea`, wantOffset: 0, wantMessage: "unclosed quote"},
+		{input: `title:"et ea`, wantOffset: 6, wantMessage: "unclosed quote"},
+		{input: ":1", wantOffset: 0, wantMessage: "expected a field name before :"},
+		{input: "a >=3", wantOffset: 2, wantMessage: "expected a field name before >="},
+		{input: "id>", wantOffset: 0, wantMessage: "expected a value after id>"},
+		{input: "a userId: b", wantOffset: 2, wantMessage: "expected a value after userId:"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			query, err := Parse(test.input)
+			var syntaxErr SyntaxError
+			if !errors.As(err, &syntaxErr) {
+				t.Fatalf("Parse(%q) = %v, %v, want a SyntaxError", test.input, query, err)
+			}
+			if syntaxErr.Offset != test.wantOffset || syntaxErr.Message != test.wantMessage {
+				t.Errorf("Parse(%q) error at %d %q, want at %d %q",
+					test.input, syntaxErr.Offset, syntaxErr.Message, test.wantOffset, test.wantMessage)
+			}
+		})
+	}
+}
+
+func TestSyntaxErrorColumn(t *testing.T) {
+	_, err := Parse("a OR")
+	if want := "column 3: expected a term after OR"; err == nil || err.Error() != want {
+		t.Errorf("Parse() error = %v, want %q", err, want)
+	}
+}
@@ -0,0 +1,31 @@
+package pkg
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// Terminals report focus changes with CSI I and CSI O once
+// EnableFocusReporting is written to them. Terminals that do not support it
+// never report, background refreshes then only pause while a prompt is open.
+const (
+	EnableFocusReporting  = "\x1b[?1004h"
+	DisableFocusReporting = "\x1b[?1004l"
+)
+
+// bubbletea v0.22.1 has no focus messages: it reads an escape followed by
+// runes it does not know as an alt key, so the reports arrive as these keys.
+// The test of ParseFocusReport fails once an upgrade changes that.
+const (
+	focusInKey  = "alt+[I"
+	focusOutKey = "alt+[O"
+)
+
+// ParseFocusReport tells whether msg is a focus report and, if so, whether
+// the terminal gained the focus.
+func ParseFocusReport(msg tea.KeyMsg) (focused bool, ok bool) {
+	switch msg.String() {
+	case focusInKey:
+		return true, true
+	case focusOutKey:
+		return false, true
+	}
+	return false, false
+}
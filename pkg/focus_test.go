@@ -0,0 +1,93 @@
+package pkg
+
+import (
+	"io"
+	"reflect"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// keyRecorder records the keys a program reads until ctrl+c.
+type keyRecorder struct {
+	keys []tea.KeyMsg
+}
+
+func (r *keyRecorder) Init() tea.Cmd { return nil }
+
+func (r *keyRecorder) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		if msg.Type == tea.KeyCtrlC {
+			return r, tea.Quit
+		}
+		r.keys = append(r.keys, msg)
+	}
+	return r, nil
+}
+
+func (r *keyRecorder) View() string { return "" }
+
+// readKeys returns the keys bubbletea reads from input, written to the
+// terminal as the given chunks.
+func readKeys(t *testing.T, chunks []string) []tea.KeyMsg {
+	t.Helper()
+	reader, writer := io.Pipe()
+	recorder := &keyRecorder{}
+	p := tea.NewProgram(recorder, tea.WithInput(reader), tea.WithOutput(io.Discard), tea.WithoutRenderer())
+	go func() {
+		for _, chunk := range append(chunks, "\x03") {
+			writer.Write([]byte(chunk))
+		}
+	}()
+
+	done := make(chan error, 1)
+	go func() { done <- p.Start() }()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Start() error = %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		p.Kill()
+		t.Fatal("the program did not read ctrl+c")
+	}
+	return recorder.keys
+}
+
+type focusReport struct {
+	focused bool
+	ok      bool
+}
+
+func TestParseFocusReport(t *testing.T) {
+	tests := []struct {
+		name   string
+		chunks []string
+		want   []focusReport
+	}{
+		{name: "focus in", chunks: []string{"\x1b[I"}, want: []focusReport{{focused: true, ok: true}}},
+		{name: "focus out", chunks: []string{"\x1b[O"}, want: []focusReport{{focused: false, ok: true}}},
+		{
+			name:   "reports read at once",
+			chunks: []string{"\x1b[O\x1b[I"},
+			want:   []focusReport{{focused: false, ok: true}, {focused: true, ok: true}},
+		},
+		{name: "typed brackets", chunks: []string{"[", "I"}, want: []focusReport{{}, {}}},
+		{name: "alt key", chunks: []string{"\x1bI"}, want: []focusReport{{}}},
+		{name: "arrow key", chunks: []string{"\x1b[A"}, want: []focusReport{{}}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var got []focusReport
+			for _, msg := range readKeys(t, test.chunks) {
+				focused, ok := ParseFocusReport(msg)
+				got = append(got, focusReport{focused: focused, ok: ok})
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("ParseFocusReport() = %+v, want %+v", got, test.want)
+			}
+		})
+	}
+}
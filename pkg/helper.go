@@ -1,6 +1,10 @@
 package pkg
 
-import "strconv"
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
 
 func Max(a, b int) int {
 	if a > b {
@@ -35,6 +39,20 @@ func TruncateString(str string, num int) string {
 	return truncated
 }
 
+// HumanizeDuration formats d with its largest unit only, e.g. "5m" or "2d".
+func HumanizeDuration(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	}
+}
+
 func CastIntToStr(a int) string {
 	str := strconv.Itoa(a)
 
@@ -0,0 +1,183 @@
+// Package httpcache stores HTTP response bodies on disk so they can be shown
+// before the network answers and revalidated with conditional requests.
+package httpcache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	appDirName    = "medium-cli"
+	entryFileExt  = ".json"
+	entryFileMode = 0o600
+	// evictEvery is the fraction of maxSize written between two evictions.
+	evictEvery = 10
+)
+
+type Entry struct {
+	// Scope keeps apart the responses of the same URL fetched with different
+	// credentials, so one source never reads the entries of another.
+	Scope        string    `json:"scope,omitempty"`
+	URL          string    `json:"url"`
+	Body         []byte    `json:"body"`
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"lastModified,omitempty"`
+	StoredAt     time.Time `json:"storedAt"`
+	// Header keeps the response headers needed to interpret the body, such as
+	// pagination links.
+	Header map[string]string `json:"header,omitempty"`
+}
+
+// Cache is a directory of entries, one file per scope and URL. File
+// modification times double as access times for LRU eviction once the
+// directory grows past maxSize bytes. The directory is only listed to evict
+// when the cache is opened and then each time a tenth of maxSize was written.
+type Cache struct {
+	dir     string
+	maxSize int64
+	// written is the number of bytes stored since the last eviction.
+	written int64
+	mu      sync.Mutex
+}
+
+func New(dir string, maxSize int64) (*Cache, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+
+	c := &Cache{dir: dir, maxSize: maxSize}
+	if err := c.evict(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// DefaultDir returns $XDG_CACHE_HOME/medium-cli, falling back to the user
+// cache directory of the platform.
+func DefaultDir() (string, error) {
+	if xdgCacheHome := os.Getenv("XDG_CACHE_HOME"); xdgCacheHome != "" {
+		return filepath.Join(xdgCacheHome, appDirName), nil
+	}
+
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(cacheDir, appDirName), nil
+}
+
+func (c *Cache) Dir() string {
+	return c.dir
+}
+
+// Get returns the entry stored for url in scope and marks it as recently used.
+func (c *Cache) Get(scope, url string) (Entry, bool) {
+	if c == nil {
+		return Entry{}, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	path := c.entryPath(scope, url)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Entry{}, false
+	}
+
+	var entry Entry
+	if err := json.Unmarshal(data, &entry); err != nil || entry.Scope != scope || entry.URL != url {
+		return Entry{}, false
+	}
+
+	now := time.Now()
+	_ = os.Chtimes(path, now, now)
+	return entry, true
+}
+
+// Put stores entry, replacing any previous one for the same scope and URL, and
+// from time to time evicts the least recently used entries if the cache grew
+// too large.
+func (c *Cache) Put(entry Entry) error {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	path := c.entryPath(entry.Scope, entry.URL)
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, entryFileMode); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return err
+	}
+
+	c.written += int64(len(data))
+	if c.written < c.maxSize/evictEvery {
+		return nil
+	}
+	return c.evict()
+}
+
+func (c *Cache) entryPath(scope, url string) string {
+	sum := sha256.Sum256([]byte(scope + "\x00" + url))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+entryFileExt)
+}
+
+func (c *Cache) evict() error {
+	c.written = 0
+	if c.maxSize <= 0 {
+		return nil
+	}
+
+	dirEntries, err := os.ReadDir(c.dir)
+	if err != nil {
+		return err
+	}
+
+	var files []os.FileInfo
+	var total int64
+	for _, dirEntry := range dirEntries {
+		if dirEntry.IsDir() || !strings.HasSuffix(dirEntry.Name(), entryFileExt) {
+			continue
+		}
+		info, err := dirEntry.Info()
+		if err != nil {
+			continue
+		}
+		files = append(files, info)
+		total += info.Size()
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].ModTime().Before(files[j].ModTime())
+	})
+
+	for _, file := range files {
+		if total <= c.maxSize {
+			break
+		}
+		err := os.Remove(filepath.Join(c.dir, file.Name()))
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		total -= file.Size()
+	}
+
+	return nil
+}
@@ -0,0 +1,119 @@
+package httpcache
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGetKeepsScopesApart(t *testing.T) {
+	cache, err := New(t.TempDir(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	const url = "https://api.example.com/todos"
+	for _, scope := range []string{"alice", "bob"} {
+		if err := cache.Put(Entry{Scope: scope, URL: url, Body: []byte(scope)}); err != nil {
+			t.Fatalf("Put() error = %v", err)
+		}
+	}
+
+	tests := []struct {
+		scope    string
+		wantBody string
+		wantOK   bool
+	}{
+		{scope: "alice", wantBody: "alice", wantOK: true},
+		{scope: "bob", wantBody: "bob", wantOK: true},
+		{scope: "carol", wantOK: false},
+		{scope: "", wantOK: false},
+	}
+	for _, test := range tests {
+		entry, ok := cache.Get(test.scope, url)
+		if ok != test.wantOK || string(entry.Body) != test.wantBody {
+			t.Errorf("Get(%q) = %q, %v, want %q, %v", test.scope, entry.Body, ok, test.wantBody, test.wantOK)
+		}
+	}
+}
+
+func TestNilCache(t *testing.T) {
+	var cache *Cache
+	if err := cache.Put(Entry{URL: "https://api.example.com"}); err != nil {
+		t.Errorf("Put() error = %v", err)
+	}
+	if _, ok := cache.Get("", "https://api.example.com"); ok {
+		t.Error("Get() found an entry in a nil cache")
+	}
+}
+
+// fillCache stores n entries of about size bytes each, the first one being
+// the least recently used.
+func fillCache(t *testing.T, cache *Cache, n, size int) {
+	t.Helper()
+	for i := 0; i < n; i++ {
+		entry := Entry{URL: "https://api.example.com/" + string(rune('a'+i)), Body: []byte(strings.Repeat("x", size))}
+		if err := cache.Put(entry); err != nil {
+			t.Fatalf("Put() error = %v", err)
+		}
+		used := time.Now().Add(time.Duration(i-n) * time.Minute)
+		if err := os.Chtimes(cache.entryPath("", entry.URL), used, used); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func countEntries(t *testing.T, dir string) int {
+	t.Helper()
+	files, err := filepath.Glob(filepath.Join(dir, "*"+entryFileExt))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return len(files)
+}
+
+func TestNewEvictsLeastRecentlyUsed(t *testing.T) {
+	dir := t.TempDir()
+	unbounded, err := New(dir, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fillCache(t, unbounded, 10, 1000)
+
+	cache, err := New(dir, 5000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := countEntries(t, dir); n >= 5 || n == 0 {
+		t.Fatalf("%d entries left after opening the cache, want fewer than 5", n)
+	}
+	if _, ok := cache.Get("", "https://api.example.com/a"); ok {
+		t.Error("the least recently used entry was kept")
+	}
+	if _, ok := cache.Get("", "https://api.example.com/j"); !ok {
+		t.Error("the most recently used entry was evicted")
+	}
+}
+
+func TestPutEvictsOnlyFromTimeToTime(t *testing.T) {
+	dir := t.TempDir()
+	cache, err := New(dir, 20000)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Less than a tenth of maxSize was written, the directory is not listed.
+	fillCache(t, cache, 1, 1000)
+	if cache.written == 0 {
+		t.Error("Put() evicted after writing less than a tenth of maxSize")
+	}
+
+	fillCache(t, cache, 26, 1000)
+	if cache.written >= cache.maxSize/evictEvery {
+		t.Errorf("%d bytes written since the last eviction, want an eviction", cache.written)
+	}
+	if n := countEntries(t, dir); n > 22 {
+		t.Errorf("%d entries left, want the cache evicted down to about 20000 bytes", n)
+	}
+}
@@ -0,0 +1,61 @@
+package pkg
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// LookupPath returns the value at path in a decoded JSON document, following
+// dot separated object keys and array indices, e.g. data.items.0.name. An
+// empty path returns the document itself.
+func LookupPath(document interface{}, path string) (interface{}, bool) {
+	value := document
+	if path == "" {
+		return value, true
+	}
+
+	for _, element := range strings.Split(path, ".") {
+		switch current := value.(type) {
+		case map[string]interface{}:
+			next, ok := current[element]
+			if !ok {
+				return nil, false
+			}
+			value = next
+		case []interface{}:
+			index, err := strconv.Atoi(element)
+			if err != nil || index < 0 || index >= len(current) {
+				return nil, false
+			}
+			value = current[index]
+		default:
+			return nil, false
+		}
+	}
+
+	return value, true
+}
+
+// RecordId returns the id field of record, read from its JSON encoding, and
+// whether it has one.
+func RecordId(record interface{}) (string, bool) {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return "", false
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var document interface{}
+	if err := decoder.Decode(&document); err != nil {
+		return "", false
+	}
+
+	id, ok := LookupPath(document, "id")
+	if !ok || id == nil {
+		return "", false
+	}
+	return fmt.Sprint(id), true
+}
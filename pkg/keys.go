@@ -1,13 +1,27 @@
 package pkg
 
-import "github.com/charmbracelet/bubbles/key"
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+)
 
 type KeyMap struct {
 	Up            key.Binding
 	Down          key.Binding
 	TogglePreview key.Binding
-	OpenGithub    key.Binding
+	OpenInBrowser key.Binding
+	Search        key.Binding
+	Export        key.Binding
+	SortColumn    key.Binding
+	SortDirection key.Binding
+	Read          key.Binding
+	Back          key.Binding
 	Refresh       key.Binding
+	NextNew       key.Binding
+	ToggleOffline key.Binding
 	PageDown      key.Binding
 	PageUp        key.Binding
 	NextSection   key.Binding
@@ -26,8 +40,12 @@ func (k KeyMap) FullHelp() [][]key.Binding {
 		{k.Up, k.Down},
 		{k.PrevSection, k.NextSection},
 		{k.PageDown, k.PageUp},
-		{k.TogglePreview, k.OpenGithub},
-		{k.Refresh, k.SwitchView},
+		{k.TogglePreview, k.OpenInBrowser},
+		{k.Read, k.Back},
+		{k.Search, k.Export},
+		{k.SortColumn, k.SortDirection},
+		{k.Refresh, k.NextNew},
+		{k.ToggleOffline, k.SwitchView},
 		{k.Help, k.Quit},
 	}
 }
@@ -62,20 +80,160 @@ var Keys = KeyMap{
 		key.WithKeys("p"),
 		key.WithHelp("p", "open in preview"),
 	),
-	OpenGithub: key.NewBinding(
+	OpenInBrowser: key.NewBinding(
 		key.WithKeys("o"),
-		key.WithHelp("o", "open in Github"),
+		key.WithHelp("o", "open in browser"),
+	),
+	Search: key.NewBinding(
+		key.WithKeys("/"),
+		key.WithHelp("/", "search"),
+	),
+	Export: key.NewBinding(
+		key.WithKeys("e"),
+		key.WithHelp("e", "export rows"),
+	),
+	SortColumn: key.NewBinding(
+		key.WithKeys("s"),
+		key.WithHelp("s", "sort by next column"),
+	),
+	SortDirection: key.NewBinding(
+		key.WithKeys("S"),
+		key.WithHelp("S", "flip sort direction"),
+	),
+	Read: key.NewBinding(
+		key.WithKeys("enter"),
+		key.WithHelp("enter", "read article"),
+	),
+	Back: key.NewBinding(
+		key.WithKeys("esc"),
+		key.WithHelp("esc", "back"),
 	),
 	Refresh: key.NewBinding(
 		key.WithKeys("r"),
 		key.WithHelp("r", "refresh"),
 	),
+	NextNew: key.NewBinding(
+		key.WithKeys("n"),
+		key.WithHelp("n", "next new row"),
+	),
+	ToggleOffline: key.NewBinding(
+		key.WithKeys("O"),
+		key.WithHelp("O", "toggle offline mode"),
+	),
 	SwitchView: key.NewBinding(
+		key.WithKeys("v"),
+		key.WithHelp("v", "switch view"),
+	),
+	Help: key.NewBinding(
 		key.WithKeys("?"),
 		key.WithHelp("?", "toggle help"),
 	),
 	Quit: key.NewBinding(
-		key.WithKeys("q", "esc", "ctrl+c"),
+		key.WithKeys("q", "ctrl+c"),
 		key.WithHelp("q", "quit"),
 	),
 }
+
+// KeyConflict is a key bound to more than one action.
+type KeyConflict struct {
+	Key     string
+	Actions []string
+}
+
+func (c KeyConflict) Error() string {
+	return fmt.Sprintf("key %q is bound to %s", c.Key, strings.Join(c.Actions, " and "))
+}
+
+// Bindings returns the bindings of k by action name, the names used in the
+// keybindings of the config file.
+func (k *KeyMap) Bindings() map[string]*key.Binding {
+	return map[string]*key.Binding{
+		"up":            &k.Up,
+		"down":          &k.Down,
+		"togglePreview": &k.TogglePreview,
+		"openInBrowser": &k.OpenInBrowser,
+		"search":        &k.Search,
+		"export":        &k.Export,
+		"sortColumn":    &k.SortColumn,
+		"sortDirection": &k.SortDirection,
+		"read":          &k.Read,
+		"back":          &k.Back,
+		"refresh":       &k.Refresh,
+		"nextNew":       &k.NextNew,
+		"toggleOffline": &k.ToggleOffline,
+		"pageDown":      &k.PageDown,
+		"pageUp":        &k.PageUp,
+		"nextSection":   &k.NextSection,
+		"prevSection":   &k.PrevSection,
+		"switchView":    &k.SwitchView,
+		"help":          &k.Help,
+		"quit":          &k.Quit,
+	}
+}
+
+// ActionNames returns the names of every action, sorted.
+func ActionNames() []string {
+	keys := Keys
+	names := make([]string, 0, len(keys.Bindings()))
+	for name := range keys.Bindings() {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NewKeyMap returns the default keys with the actions in overrides bound to
+// the given keys instead. It fails on unknown actions and on keys bound to
+// several actions.
+func NewKeyMap(overrides map[string][]string) (KeyMap, error) {
+	keys := Keys
+	if err := keys.Remap(overrides); err != nil {
+		return keys, err
+	}
+
+	if conflicts := keys.Conflicts(); len(conflicts) > 0 {
+		return keys, conflicts[0]
+	}
+	return keys, nil
+}
+
+// Remap binds the actions in overrides to the given keys, keeping their help
+// text. Conflicts are left for the caller to check.
+func (k *KeyMap) Remap(overrides map[string][]string) error {
+	bindings := k.Bindings()
+	for name, overrideKeys := range overrides {
+		binding, ok := bindings[name]
+		if !ok {
+			return fmt.Errorf("unknown action %q", name)
+		}
+		if len(overrideKeys) == 0 {
+			return fmt.Errorf("action %q has no keys", name)
+		}
+		*binding = key.NewBinding(
+			key.WithKeys(overrideKeys...),
+			key.WithHelp(strings.Join(overrideKeys, "/"), binding.Help().Desc),
+		)
+	}
+	return nil
+}
+
+// Conflicts returns the keys bound to more than one action, sorted by key.
+func (k KeyMap) Conflicts() []KeyConflict {
+	actionsByKey := map[string][]string{}
+	for _, name := range ActionNames() {
+		for _, boundKey := range k.Bindings()[name].Keys() {
+			actionsByKey[boundKey] = append(actionsByKey[boundKey], name)
+		}
+	}
+
+	var conflicts []KeyConflict
+	for boundKey, actions := range actionsByKey {
+		if len(actions) > 1 {
+			conflicts = append(conflicts, KeyConflict{Key: boundKey, Actions: actions})
+		}
+	}
+	sort.Slice(conflicts, func(i, j int) bool {
+		return conflicts[i].Key < conflicts[j].Key
+	})
+	return conflicts
+}
@@ -0,0 +1,226 @@
+// Package output prints the rows of a section for the list and get commands,
+// and writes them to the files they are exported to.
+package output
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"unicode/utf8"
+
+	"github.com/mehmetcantas/medium-cli/components/table"
+	"gopkg.in/yaml.v3"
+)
+
+type Format string
+
+const (
+	JSON     Format = "json"
+	YAML     Format = "yaml"
+	CSV      Format = "csv"
+	TSV      Format = "tsv"
+	Table    Format = "table"
+	Markdown Format = "markdown"
+)
+
+var Formats = []Format{JSON, YAML, CSV, TSV, Table, Markdown}
+
+// extensionFormats are the formats of files by extension.
+var extensionFormats = map[string]Format{
+	".json": JSON,
+	".yaml": YAML,
+	".yml":  YAML,
+	".csv":  CSV,
+	".tsv":  TSV,
+	".txt":  Table,
+	".md":   Markdown,
+}
+
+// ParseFormat returns the format named name.
+func ParseFormat(name string) (Format, error) {
+	for _, format := range Formats {
+		if string(format) == name {
+			return format, nil
+		}
+	}
+
+	names := make([]string, 0, len(Formats))
+	for _, format := range Formats {
+		names = append(names, string(format))
+	}
+	return "", fmt.Errorf("unknown output format %q, expected one of %s", name, strings.Join(names, ", "))
+}
+
+// Write prints rows to w in format. JSON and YAML print value, the records the
+// rows were built from, the other formats print the cells of rows under the
+// titles of columns.
+func Write(w io.Writer, format Format, columns []table.Column, rows []table.Row, value interface{}) error {
+	switch format {
+	case JSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(value)
+	case YAML:
+		return writeYAML(w, value)
+	case CSV:
+		return writeCSV(w, ',', columns, rows)
+	case TSV:
+		return writeCSV(w, '\t', columns, rows)
+	case Table:
+		return writeTable(w, columns, rows)
+	case Markdown:
+		return writeMarkdown(w, columns, rows)
+	default:
+		return fmt.Errorf("unknown output format %q", format)
+	}
+}
+
+// FormatForPath returns the format of the file at path from its extension.
+func FormatForPath(path string) (Format, error) {
+	extension := strings.ToLower(filepath.Ext(path))
+	if format, ok := extensionFormats[extension]; ok {
+		return format, nil
+	}
+
+	extensions := make([]string, 0, len(extensionFormats))
+	for extension := range extensionFormats {
+		extensions = append(extensions, extension)
+	}
+	sort.Strings(extensions)
+	return "", fmt.Errorf("unknown file extension %q, expected one of %s", extension, strings.Join(extensions, ", "))
+}
+
+// WriteFile writes rows to a new file at path, in the format of its extension.
+// It fails with an error matching os.ErrExist when the file already exists.
+func WriteFile(path string, columns []table.Column, rows []table.Row, records []interface{}) error {
+	format, err := FormatForPath(path)
+	if err != nil {
+		return err
+	}
+	if records == nil {
+		records = []interface{}{}
+	}
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o666)
+	if err != nil {
+		return err
+	}
+	if err := Write(file, format, columns, rows, records); err != nil {
+		file.Close()
+		os.Remove(path)
+		return err
+	}
+	return file.Close()
+}
+
+// writeYAML prints value with the field names of its JSON encoding, which the
+// records of every section have.
+func writeYAML(w io.Writer, value interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	var document interface{}
+	if err := json.Unmarshal(data, &document); err != nil {
+		return err
+	}
+
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(document); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+func writeCSV(w io.Writer, separator rune, columns []table.Column, rows []table.Row) error {
+	writer := csv.NewWriter(w)
+	writer.Comma = separator
+
+	if err := writer.Write(titles(columns)); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		if err := writer.Write(plainCells(row)); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// writeTable aligns the cells under the column titles. Cells of columns with a
+// width are cut to it, the others are shown whole.
+func writeTable(w io.Writer, columns []table.Column, rows []table.Row) error {
+	writer := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	lines := [][]string{titles(columns)}
+	for _, row := range rows {
+		lines = append(lines, plainCells(row))
+	}
+	for _, line := range lines {
+		for i, cell := range line {
+			if i < len(columns) && columns[i].Width != nil {
+				cell = truncate(cell, *columns[i].Width)
+			}
+			line[i] = strings.NewReplacer("\t", " ", "\n", " ").Replace(cell)
+		}
+		if _, err := fmt.Fprintln(writer, strings.Join(line, "\t")); err != nil {
+			return err
+		}
+	}
+	return writer.Flush()
+}
+
+func writeMarkdown(w io.Writer, columns []table.Column, rows []table.Row) error {
+	lines := []string{markdownRow(titles(columns))}
+	separators := make([]string, 0, len(columns))
+	for range columns {
+		separators = append(separators, "---")
+	}
+	lines = append(lines, markdownRow(separators))
+	for _, row := range rows {
+		lines = append(lines, markdownRow(plainCells(row)))
+	}
+
+	_, err := fmt.Fprintln(w, strings.Join(lines, "\n"))
+	return err
+}
+
+func markdownRow(cells []string) string {
+	escaped := make([]string, 0, len(cells))
+	for _, cell := range cells {
+		cell = strings.NewReplacer("|", "\\|", "\n", " ").Replace(cell)
+		escaped = append(escaped, cell)
+	}
+	return "| " + strings.Join(escaped, " | ") + " |"
+}
+
+func titles(columns []table.Column) []string {
+	titles := make([]string, 0, len(columns))
+	for _, column := range columns {
+		titles = append(titles, column.Title)
+	}
+	return titles
+}
+
+func plainCells(row table.Row) []string {
+	cells := make([]string, 0, len(row))
+	for _, cell := range row {
+		cells = append(cells, strings.TrimSpace(table.StripStyles(cell)))
+	}
+	return cells
+}
+
+func truncate(s string, width int) string {
+	if width < 1 || utf8.RuneCountInString(s) <= width {
+		return s
+	}
+	return string([]rune(s)[:width-1]) + "…"
+}
@@ -0,0 +1,46 @@
+package output
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mehmetcantas/medium-cli/components/table"
+)
+
+func TestWriteFile(t *testing.T) {
+	columns := []table.Column{{Title: "ID"}, {Title: "Title"}}
+	rows := []table.Row{{"1", "first"}}
+	records := []interface{}{map[string]interface{}{"id": 1, "title": "first"}}
+
+	path := filepath.Join(t.TempDir(), "rows.csv")
+	if err := WriteFile(path, columns, rows, records); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "ID,Title\n1,first\n"; string(data) != want {
+		t.Errorf("WriteFile() wrote %q, want %q", data, want)
+	}
+
+	err = WriteFile(path, columns, []table.Row{{"2", "second"}}, nil)
+	if !errors.Is(err, os.ErrExist) {
+		t.Errorf("WriteFile() to an existing file error = %v, want %v", err, os.ErrExist)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "ID,Title\n1,first\n" {
+		t.Errorf("WriteFile() changed the existing file to %q", data)
+	}
+}
+
+func TestWriteFileUnknownExtension(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rows.xls")
+	if err := WriteFile(path, nil, nil, nil); err == nil {
+		t.Error("WriteFile() of an unknown extension succeeded, want an error")
+	}
+	if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("WriteFile() created %s", path)
+	}
+}
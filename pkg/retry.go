@@ -0,0 +1,22 @@
+package pkg
+
+import "context"
+
+// RetryNotifyFunc is called before a failed request is attempted again.
+// attempt is the number of the upcoming attempt, starting at 2.
+type RetryNotifyFunc func(attempt, maxAttempts int, err error)
+
+type retryNotifyKey struct{}
+
+// WithRetryNotify returns a context carrying fn, so that clients deep in a
+// fetch can report their retries to whoever started it.
+func WithRetryNotify(ctx context.Context, fn RetryNotifyFunc) context.Context {
+	return context.WithValue(ctx, retryNotifyKey{}, fn)
+}
+
+// NotifyRetry calls the RetryNotifyFunc attached to ctx, if any.
+func NotifyRetry(ctx context.Context, attempt, maxAttempts int, err error) {
+	if fn, ok := ctx.Value(retryNotifyKey{}).(RetryNotifyFunc); ok && fn != nil {
+		fn(attempt, maxAttempts, err)
+	}
+}
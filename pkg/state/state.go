@@ -0,0 +1,97 @@
+// Package state saves where the user was in the UI when the program quit, so
+// the next launch can start from there.
+package state
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+)
+
+const (
+	appDirName    = "medium-cli"
+	stateFileName = "state.json"
+	stateFileMode = 0o600
+)
+
+type State struct {
+	// View is the view that was shown.
+	View string `json:"view"`
+	// Views are the states of the views by name, only for views that were
+	// shown at least once.
+	Views   map[string]ViewState `json:"views,omitempty"`
+	Preview PreviewState         `json:"preview"`
+}
+
+type ViewState struct {
+	// CurrSection is the title of the selected section.
+	CurrSection string `json:"currSection"`
+	// Sections are the states of the sections by title.
+	Sections map[string]SectionState `json:"sections,omitempty"`
+}
+
+type SectionState struct {
+	// Cursor is the position of the cursor among the shown rows.
+	Cursor int `json:"cursor"`
+	// Search is the search applied to the rows.
+	Search string `json:"search,omitempty"`
+}
+
+type PreviewState struct {
+	Open bool `json:"open"`
+	// Width is the width of the preview in percent of the screen. A width set
+	// in the config file wins over it.
+	Width int `json:"width,omitempty"`
+}
+
+// DefaultPath returns $XDG_STATE_HOME/medium-cli/state.json, falling back to
+// ~/.local/state as the XDG spec does.
+func DefaultPath() (string, error) {
+	if xdgStateHome := os.Getenv("XDG_STATE_HOME"); xdgStateHome != "" {
+		return filepath.Join(xdgStateHome, appDirName, stateFileName), nil
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(homeDir, ".local", "state", appDirName, stateFileName), nil
+}
+
+// Load reads the state saved at path. It returns nil without error when none
+// was saved yet.
+func Load(path string) (*State, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var state State
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
+
+// Save writes state to path, replacing the previous one at once so a crash
+// cannot leave a partial file behind.
+func Save(path string, state State) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, stateFileMode); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
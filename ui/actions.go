@@ -0,0 +1,105 @@
+package ui
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mehmetcantas/medium-cli/config"
+)
+
+type actionFinishedMsg struct {
+	description string
+	output      string
+	err         error
+}
+
+// getCurrSectionActions returns the actions of the current section.
+func (m *Model) getCurrSectionActions() []config.ActionConfig {
+	if m.getCurrSection() == nil {
+		return nil
+	}
+	sectionConfigs := m.ctx.GetViewSectionsConfig()
+	if m.currSectionId >= len(sectionConfigs) {
+		return nil
+	}
+
+	return sectionConfigs[m.currSectionId].Actions
+}
+
+// getActionBindings returns the keys of the current section's actions, for
+// the help.
+func (m *Model) getActionBindings() []key.Binding {
+	var bindings []key.Binding
+	for _, action := range m.getCurrSectionActions() {
+		bindings = append(bindings, key.NewBinding(
+			key.WithKeys(action.Key),
+			key.WithHelp(action.Key, action.GetDescription()),
+		))
+	}
+
+	return bindings
+}
+
+// runCurrSectionAction runs the action of the current section bound to the
+// pressed key, if any.
+func (m *Model) runCurrSectionAction(msg tea.KeyMsg) tea.Cmd {
+	for _, action := range m.getCurrSectionActions() {
+		if action.Key == msg.String() {
+			return m.runAction(action)
+		}
+	}
+
+	return nil
+}
+
+// runAction runs the command of action on the selected row, in the
+// background or with the terminal handed over to it.
+func (m *Model) runAction(action config.ActionConfig) tea.Cmd {
+	description := action.GetDescription()
+	record := m.getCurrSection().GetCurrRow()
+	if record == nil {
+		return m.help.SetStatus(fmt.Sprintf("Nothing selected to %s", description), true)
+	}
+	command, err := action.BuildCommand(record)
+	if err != nil {
+		return m.help.SetStatus(fmt.Sprintf("Could not build the command of %q: %v", description, err), true)
+	}
+
+	if action.Suspend {
+		return tea.ExecProcess(exec.Command("sh", "-c", command), func(err error) tea.Msg {
+			return actionFinishedMsg{description: description, err: err}
+		})
+	}
+
+	ctx := m.ctx.Context
+	return func() tea.Msg {
+		output, err := exec.CommandContext(ctx, "sh", "-c", command).CombinedOutput()
+		return actionFinishedMsg{description: description, output: string(output), err: err}
+	}
+}
+
+// onActionFinished shows the exit status and the last line of output of an
+// action.
+func (m *Model) onActionFinished(msg actionFinishedMsg) tea.Cmd {
+	output := strings.TrimSpace(msg.output)
+	if i := strings.LastIndex(output, "\n"); i >= 0 {
+		output = output[i+1:]
+	}
+
+	var status string
+	switch {
+	case msg.err != nil && output != "":
+		status = fmt.Sprintf("%s failed: %v: %s", msg.description, msg.err, output)
+	case msg.err != nil:
+		status = fmt.Sprintf("%s failed: %v", msg.description, msg.err)
+	case output != "":
+		status = fmt.Sprintf("%s: %s", msg.description, output)
+	default:
+		status = fmt.Sprintf("%s done", msg.description)
+	}
+
+	return m.help.SetStatus(status, msg.err != nil)
+}
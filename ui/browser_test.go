@@ -0,0 +1,76 @@
+package ui
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mehmetcantas/medium-cli/components/placeholdersection"
+	"github.com/mehmetcantas/medium-cli/components/section"
+	"github.com/mehmetcantas/medium-cli/config"
+)
+
+func TestOpenCurrRowInBrowser(t *testing.T) {
+	tests := []struct {
+		name        string
+		urlTemplate string
+		launchErr   error
+		wantOpened  []string
+		wantMsg     bool
+	}{
+		{
+			name:        "opens the URL of the row",
+			urlTemplate: "https://example.com/posts/{{.Id}}",
+			wantOpened:  []string{"https://example.com/posts/7"},
+			wantMsg:     true,
+		},
+		{
+			name:        "reports the launcher error",
+			urlTemplate: "https://example.com/posts/{{.Id}}",
+			launchErr:   errors.New("no browser"),
+			wantOpened:  []string{"https://example.com/posts/7"},
+			wantMsg:     true,
+		},
+		{
+			name:        "no URL template",
+			urlTemplate: "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var opened []string
+			m := NewModel(Options{Launcher: func(url string) error {
+				opened = append(opened, url)
+				return test.launchErr
+			}})
+			sectionConfig := config.SectionConfig{Title: "Posts", Filters: "posts", URLTemplate: test.urlTemplate}
+			m.ctx.Config = &config.Config{PlaceholderSections: []config.SectionConfig{sectionConfig}}
+			m.ctx.View = config.PlaceholderView
+
+			sectionModel := placeholdersection.NewModel(0, &m.ctx, sectionConfig, config.PlaceholderView)
+			updated, _ := sectionModel.Update(section.SectionRowsFetchedMsg{
+				Records:     []interface{}{placeholdersection.PlaceholderModel{Id: 7, Title: "seventh"}},
+				FetchResult: section.FetchResult{Generation: sectionModel.Generation},
+			})
+			m.placeholders = []section.Section{updated}
+
+			cmd := m.openCurrRowInBrowser()
+			if cmd == nil {
+				t.Fatal("openCurrRowInBrowser() returned no command")
+			}
+			// Without a URL the command only clears the status later on.
+			if test.wantMsg {
+				msg, ok := cmd().(urlOpenedMsg)
+				if !ok {
+					t.Fatal("openCurrRowInBrowser() did not send a urlOpenedMsg")
+				}
+				if msg.err != test.launchErr {
+					t.Errorf("urlOpenedMsg.err = %v, want %v", msg.err, test.launchErr)
+				}
+			}
+			if len(opened) != len(test.wantOpened) || (len(opened) > 0 && opened[0] != test.wantOpened[0]) {
+				t.Errorf("launcher opened %v, want %v", opened, test.wantOpened)
+			}
+		})
+	}
+}
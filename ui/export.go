@@ -0,0 +1,85 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mehmetcantas/medium-cli/components/export"
+	"github.com/mehmetcantas/medium-cli/pkg/output"
+)
+
+type exportFinishedMsg struct {
+	path    string
+	numRows int
+	err     error
+}
+
+// defaultExportPath names the export of the current section after its title
+// and the current time.
+func (m *Model) defaultExportPath() string {
+	title := ""
+	sectionConfigs := m.ctx.GetViewSectionsConfig()
+	if m.currSectionId < len(sectionConfigs) {
+		title = sectionConfigs[m.currSectionId].Title
+	}
+	return export.DefaultFileName(title, time.Now())
+}
+
+// updateExport handles the keys while the export path is edited.
+func (m *Model) updateExport(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case m.isPromptQuit(msg):
+		return m.quit()
+	case key.Matches(msg, m.ctx.Keys.Back):
+		m.export.Close()
+	case msg.Type == tea.KeyEnter:
+		m.export.Close()
+		return m.exportCurrSection(m.export.Value())
+	default:
+		var cmd tea.Cmd
+		m.export, cmd = m.export.Update(msg)
+		return cmd
+	}
+
+	return nil
+}
+
+// exportCurrSection writes the shown rows of the current section to path, in
+// the order they are shown. The file is written in a command, slow disks do
+// not block the UI.
+func (m *Model) exportCurrSection(path string) tea.Cmd {
+	currSection := m.getCurrSection()
+	if currSection == nil {
+		return nil
+	}
+	if path == "" {
+		return m.help.SetStatus("Nothing exported, the path is empty", true)
+	}
+
+	records, rows := currSection.GetShownRows()
+	columns := currSection.GetSectionColumns()
+	return func() tea.Msg {
+		err := output.WriteFile(path, columns, rows, records)
+		return exportFinishedMsg{path: path, numRows: len(rows), err: err}
+	}
+}
+
+func (m *Model) onExportFinished(msg exportFinishedMsg) tea.Cmd {
+	if errors.Is(msg.err, os.ErrExist) {
+		// Let the path be changed rather than replacing the file.
+		return m.export.Refuse(msg.path, fmt.Sprintf("%s already exists, choose another path", msg.path))
+	}
+	if msg.err != nil {
+		return m.help.SetStatus(fmt.Sprintf("Could not export to %s: %v", msg.path, msg.err), true)
+	}
+
+	noun := "rows"
+	if msg.numRows == 1 {
+		noun = "row"
+	}
+	return m.help.SetStatus(fmt.Sprintf("Exported %d %s to %s", msg.numRows, noun, msg.path), false)
+}
@@ -0,0 +1,90 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mehmetcantas/medium-cli/components/placeholdersection"
+	"github.com/mehmetcantas/medium-cli/components/section"
+	"github.com/mehmetcantas/medium-cli/config"
+)
+
+// newTestModel returns a model whose current section shows records.
+func newTestModel(records ...interface{}) Model {
+	m := NewModel(Options{})
+	sectionConfig := config.SectionConfig{Title: "Posts", Filters: "posts"}
+	m.ctx.Config = &config.Config{PlaceholderSections: []config.SectionConfig{sectionConfig}}
+	m.ctx.View = config.PlaceholderView
+	m.onWindowSizeChanged(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m.syncProgramContext()
+
+	sectionModel := placeholdersection.NewModel(0, &m.ctx, sectionConfig, config.PlaceholderView)
+	updated, _ := sectionModel.Update(section.SectionRowsFetchedMsg{
+		Records:     records,
+		FetchResult: section.FetchResult{Generation: sectionModel.Generation},
+	})
+	m.placeholders = []section.Section{updated}
+	return m
+}
+
+func TestExportCurrSection(t *testing.T) {
+	tests := []struct {
+		name        string
+		existing    bool
+		wantErr     bool
+		wantRefused bool
+	}{
+		{name: "new file"},
+		{name: "existing file", existing: true, wantErr: true, wantRefused: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m := newTestModel(
+				placeholdersection.PlaceholderModel{Id: 1, Title: "first"},
+				placeholdersection.PlaceholderModel{Id: 2, Title: "second"},
+			)
+			path := filepath.Join(t.TempDir(), "posts.csv")
+			if test.existing {
+				if err := os.WriteFile(path, []byte("kept"), 0o600); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			cmd := m.exportCurrSection(path)
+			if cmd == nil {
+				t.Fatal("exportCurrSection() returned no command")
+			}
+			// Nothing is written before the command runs.
+			if _, err := os.Stat(path); !test.existing && err == nil {
+				t.Error("exportCurrSection() wrote the file before its command ran")
+			}
+
+			msg, ok := cmd().(exportFinishedMsg)
+			if !ok {
+				t.Fatal("the export command did not send an exportFinishedMsg")
+			}
+			if (msg.err != nil) != test.wantErr || msg.numRows != 2 || msg.path != path {
+				t.Errorf("exportFinishedMsg = %+v, want 2 rows written to %s", msg, path)
+			}
+
+			m.onExportFinished(msg)
+			if m.export.IsEditing != test.wantRefused {
+				t.Errorf("export prompt open = %v, want %v", m.export.IsEditing, test.wantRefused)
+			}
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if test.existing && string(data) != "kept" {
+				t.Errorf("the existing file was replaced with %q", data)
+			}
+			if !test.existing && !strings.Contains(string(data), "second") {
+				t.Errorf("exported %q, want both rows", data)
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mehmetcantas/medium-cli/components/section"
+	"github.com/mehmetcantas/medium-cli/config"
+	"github.com/mehmetcantas/medium-cli/ui/screencontext"
+)
+
+// loadScreenWidth and loadScreenHeight are the screen size sections are
+// loaded with out of the interactive program.
+const (
+	loadScreenWidth  = 160
+	loadScreenHeight = 40
+)
+
+// LoadSection fetches the rows of the section titled title, in either view,
+// without the interactive program. Once the rows are loaded, loadMore is
+// called, when not nil, to tell whether to fetch the next page too. The error
+// is the one of the config or of the last fetch.
+func LoadSection(options Options, title string, loadMore func(section.Section) bool) (section.Section, error) {
+	m := NewModel(options)
+	defer m.cancelFetches()
+
+	msg := m.initScreen()
+	if err, ok := msg.(errMsg); ok {
+		return nil, err.error
+	}
+	init := msg.(initMsg)
+
+	ctx := screencontext.ScreenContext{
+		ScreenWidth:       loadScreenWidth,
+		ScreenHeight:      loadScreenHeight,
+		MainContentWidth:  loadScreenWidth,
+		MainContentHeight: loadScreenHeight,
+		Config:            &init.Config,
+		Context:           m.ctx.Context,
+		Cache:             init.Cache,
+		Offline:           options.Offline,
+		Sources:           init.Sources,
+		Keys:              init.Keys,
+	}
+	sectionModel := findSection(&ctx, title)
+	if sectionModel == nil {
+		return nil, fmt.Errorf("no section titled %q, expected one of %s", title, strings.Join(sectionTitles(init.Config), ", "))
+	}
+
+	p := tea.NewProgram(
+		sectionLoader{section: sectionModel, loadMore: loadMore},
+		tea.WithoutRenderer(),
+		// No keys are read, and the terminal is left as is.
+		tea.WithInput(strings.NewReader("")),
+		tea.WithOutput(io.Discard),
+	)
+	final, err := p.StartReturningModel()
+	if err != nil {
+		return nil, err
+	}
+	loader := final.(sectionLoader)
+	return loader.section, loader.err
+}
+
+// findSection creates the section titled title, ignoring case, and sets the
+// view of ctx to the one it is in.
+func findSection(ctx *screencontext.ScreenContext, title string) section.Section {
+	for _, view := range []config.ViewType{config.PlaceholderView, config.OtherView} {
+		for i, sectionConfig := range ctx.Config.GetViewSections(view) {
+			if strings.EqualFold(sectionConfig.Title, title) {
+				ctx.View = view
+				return newSection(i, ctx, sectionConfig, view)
+			}
+		}
+	}
+	return nil
+}
+
+func sectionTitles(settings config.Config) []string {
+	var titles []string
+	for _, view := range []config.ViewType{config.PlaceholderView, config.OtherView} {
+		for _, sectionConfig := range settings.GetViewSections(view) {
+			titles = append(titles, fmt.Sprintf("%q", sectionConfig.Title))
+		}
+	}
+	return titles
+}
+
+// sectionLoader runs the fetches of a single section until its rows are
+// loaded, in place of the interactive program.
+type sectionLoader struct {
+	section  section.Section
+	loadMore func(section.Section) bool
+	err      error
+}
+
+func (l sectionLoader) Init() tea.Cmd {
+	return l.section.FetchSectionRows()
+}
+
+func (l sectionLoader) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	sectionMsg, ok := msg.(section.SectionMsg)
+	if !ok {
+		return l, nil
+	}
+
+	var cmd tea.Cmd
+	l.section, cmd = l.section.Update(sectionMsg)
+	fetchedMsg, ok := msg.(section.FetchedMsg)
+	if !ok {
+		return l, cmd
+	}
+
+	result := fetchedMsg.GetFetchResult()
+	switch {
+	case result.Stale:
+		// The fresh rows follow the cached ones.
+		return l, cmd
+	case result.Err != nil:
+		l.err = result.Err
+		return l, tea.Quit
+	case cmd != nil:
+		// The section keeps loading pages for its filter.
+		return l, cmd
+	}
+
+	if result.HasMore && l.loadMore != nil && l.loadMore(l.section) {
+		// The next page is fetched once the cursor gets close to the last row,
+		// as it is in the interactive program.
+		for i := 0; i < l.section.NumRows(); i++ {
+			l.section.NextRow()
+		}
+		if cmd = l.section.FetchNextPageRows(); cmd != nil {
+			return l, cmd
+		}
+	}
+	return l, tea.Quit
+}
+
+func (l sectionLoader) View() string {
+	return ""
+}
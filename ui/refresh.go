@@ -0,0 +1,123 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// refreshTickMsg is sent when a section is due for a background refresh.
+type refreshTickMsg struct {
+	key    sectionKey
+	tickId int
+}
+
+// scheduleRefresh schedules the next background refresh of a section, after
+// its refresh interval, replacing the one scheduled before.
+func (m *Model) scheduleRefresh(key sectionKey) tea.Cmd {
+	sectionConfigs := m.ctx.Config.GetViewSections(key.view)
+	if key.id >= len(sectionConfigs) {
+		return nil
+	}
+	interval := sectionConfigs[key.id].GetRefreshInterval(m.ctx.Config.Defaults.RefreshInterval)
+	if interval <= 0 {
+		return nil
+	}
+
+	m.refreshTickIds[key]++
+	tickId := m.refreshTickIds[key]
+	return tea.Tick(interval, func(time.Time) tea.Msg {
+		return refreshTickMsg{key: key, tickId: tickId}
+	})
+}
+
+// onRefreshTick refreshes the section of the tick, or waits for refreshing
+// to resume. Offline sections are refreshed when going back online.
+func (m *Model) onRefreshTick(msg refreshTickMsg) tea.Cmd {
+	if msg.tickId != m.refreshTickIds[msg.key] || m.ctx.Offline {
+		return nil
+	}
+	if m.isRefreshPaused() {
+		m.refreshesDue[msg.key] = true
+		return nil
+	}
+
+	return m.refreshSection(msg.key)
+}
+
+// isRefreshPaused reports whether background refreshes wait, while the
+// terminal is not focused or the user types in a prompt.
+func (m *Model) isRefreshPaused() bool {
+	return m.isUnfocused || m.search.IsEditing || m.export.IsEditing
+}
+
+// refreshDueSections refreshes the sections that came due while refreshing
+// was paused, once it resumes.
+func (m *Model) refreshDueSections() tea.Cmd {
+	if len(m.refreshesDue) == 0 || m.isRefreshPaused() {
+		return nil
+	}
+
+	var cmds []tea.Cmd
+	for key := range m.refreshesDue {
+		if !m.ctx.Offline {
+			cmds = append(cmds, m.refreshSection(key))
+		}
+	}
+	m.refreshesDue = map[sectionKey]bool{}
+	return tea.Batch(cmds...)
+}
+
+// refreshSection fetches the rows of a section again. The cursor stays on the
+// record it is on when the rows arrive, see keepCursor.
+func (m *Model) refreshSection(key sectionKey) tea.Cmd {
+	sections := m.getViewSections(key.view)
+	if key.id >= len(sections) {
+		return nil
+	}
+	return sections[key.id].RefreshSectionRows()
+}
+
+// showChanges tells in the status what the fetch of the current section
+// changed in its rows.
+func (m *Model) showChanges(key sectionKey) tea.Cmd {
+	if key.view != m.ctx.View || key.id != m.currSectionId {
+		return nil
+	}
+	currSection := m.getCurrSection()
+	if currSection == nil || currSection.GetChanges().IsZero() {
+		return nil
+	}
+
+	status := currSection.GetChanges().String()
+	if currSection.GetUnreadCount() > 0 {
+		status += fmt.Sprintf(" · press %s to jump to the new rows", m.ctx.Keys.NextNew.Help().Key)
+	}
+	return m.help.SetStatus(status, false)
+}
+
+// showRefreshError tells in the status why the fetch of the current section
+// failed, when it kept showing the rows it was to replace.
+func (m *Model) showRefreshError(key sectionKey, err error) tea.Cmd {
+	if key.view != m.ctx.View || key.id != m.currSectionId {
+		return nil
+	}
+	currSection := m.getCurrSection()
+	if currSection == nil || currSection.NumRows() == 0 {
+		return nil
+	}
+
+	return m.help.SetStatus(fmt.Sprintf("Could not refresh, showing the previous rows: %v", err), true)
+}
+
+// getUnreadCounts returns the numbers of new rows not looked at yet in the
+// sections of the current view, shown on their tabs.
+func (m *Model) getUnreadCounts() []int {
+	sections := m.getCurrentViewSections()
+	counts := make([]int, len(sections))
+	for i, currSection := range sections {
+		counts[i] = currSection.GetUnreadCount()
+	}
+	return counts
+}
@@ -0,0 +1,55 @@
+package ui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mehmetcantas/medium-cli/components/placeholdersection"
+	"github.com/mehmetcantas/medium-cli/components/section"
+	"github.com/mehmetcantas/medium-cli/config"
+)
+
+func TestRefreshKeepsCursorOnRecord(t *testing.T) {
+	post := func(id int) interface{} {
+		return placeholdersection.PlaceholderModel{Id: id, Title: "post"}
+	}
+	tests := []struct {
+		name string
+		// moves is how many rows the cursor moves down while the refresh
+		// is in flight.
+		moves  int
+		fresh  []interface{}
+		wantId int
+	}{
+		{name: "record moved down", fresh: []interface{}{post(4), post(1), post(2), post(3)}, wantId: 1},
+		{name: "moved during the refresh", moves: 1, fresh: []interface{}{post(4), post(1), post(2), post(3)}, wantId: 2},
+		{name: "moved to a removed record", moves: 2, fresh: []interface{}{post(1), post(2)}, wantId: 2},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m := newTestModel(post(1), post(2), post(3))
+			key := sectionKey{view: config.PlaceholderView, id: 0}
+			if cmd := m.refreshSection(key); cmd == nil {
+				t.Fatal("refreshSection() returned no command")
+			}
+			for i := 0; i < test.moves; i++ {
+				updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyDown})
+				m = *updated.(*Model)
+			}
+
+			generation := m.placeholders[0].(*section.Model).Generation
+			updated, _ := m.Update(section.SectionRowsFetchedMsg{
+				View:        key.view,
+				Records:     test.fresh,
+				FetchResult: section.FetchResult{Generation: generation},
+			})
+			m = *updated.(*Model)
+
+			row, ok := m.getCurrSection().GetCurrRow().(placeholdersection.PlaceholderModel)
+			if !ok || row.Id != test.wantId {
+				t.Errorf("cursor on %+v, want the record %d", row, test.wantId)
+			}
+		})
+	}
+}
@@ -1,6 +1,12 @@
 package screencontext
 
-import "github.com/mehmetcantas/medium-cli/config"
+import (
+	"context"
+
+	"github.com/mehmetcantas/medium-cli/config"
+	"github.com/mehmetcantas/medium-cli/pkg"
+	"github.com/mehmetcantas/medium-cli/pkg/httpcache"
+)
 
 type ScreenContext struct {
 	ScreenHeight      int
@@ -9,10 +15,23 @@ type ScreenContext struct {
 	MainContentHeight int
 	Config            *config.Config
 	View              config.ViewType
+	// Context is cancelled when the program quits, aborting every request
+	// that is still in flight.
+	Context context.Context
+	// Cache is shared by every client, and nil when caching is disabled.
+	Cache *httpcache.Cache
+	// Offline makes every section read its rows from the cache only.
+	Offline bool
+	// Sources are the configured sources by name, with their secrets read.
+	Sources map[string]config.Source
+	// Keys are the keybindings, the defaults until the config is read.
+	Keys pkg.KeyMap
 }
 
 func (ctx *ScreenContext) GetViewSectionsConfig() []config.SectionConfig {
+	return ctx.Config.GetViewSections(ctx.View)
+}
 
-	return ctx.Config.PlaceholderSections
-
+func (ctx *ScreenContext) GetSource(name string) config.Source {
+	return ctx.Sources[name]
 }
@@ -0,0 +1,38 @@
+package ui
+
+import (
+	"github.com/mehmetcantas/medium-cli/components/commentsection"
+	"github.com/mehmetcantas/medium-cli/components/feedsection"
+	"github.com/mehmetcantas/medium-cli/components/photosection"
+	"github.com/mehmetcantas/medium-cli/components/placeholdersection"
+	"github.com/mehmetcantas/medium-cli/components/restsection"
+	"github.com/mehmetcantas/medium-cli/components/section"
+	"github.com/mehmetcantas/medium-cli/components/usersection"
+	"github.com/mehmetcantas/medium-cli/config"
+	"github.com/mehmetcantas/medium-cli/ui/screencontext"
+)
+
+// newSection creates the section matching the configured type. Sections
+// without a type are placeholder sections.
+func newSection(id int, ctx *screencontext.ScreenContext, sectionConfig config.SectionConfig, view config.ViewType) section.Section {
+	switch sectionConfig.Type {
+	case config.CommentsSection:
+		sectionModel := commentsection.NewModel(id, ctx, sectionConfig, view)
+		return &sectionModel
+	case config.PhotosSection:
+		sectionModel := photosection.NewModel(id, ctx, sectionConfig, view)
+		return &sectionModel
+	case config.UsersSection:
+		sectionModel := usersection.NewModel(id, ctx, sectionConfig, view)
+		return &sectionModel
+	case config.FeedSection:
+		sectionModel := feedsection.NewModel(id, ctx, sectionConfig, view)
+		return &sectionModel
+	case config.RESTSection:
+		sectionModel := restsection.NewModel(id, ctx, sectionConfig, view)
+		return &sectionModel
+	default:
+		sectionModel := placeholdersection.NewModel(id, ctx, sectionConfig, view)
+		return &sectionModel
+	}
+}
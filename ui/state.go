@@ -0,0 +1,191 @@
+package ui
+
+import (
+	"log"
+
+	"github.com/mehmetcantas/medium-cli/components/section"
+	"github.com/mehmetcantas/medium-cli/config"
+	"github.com/mehmetcantas/medium-cli/pkg"
+	"github.com/mehmetcantas/medium-cli/pkg/state"
+)
+
+// sectionKey identifies a section among the sections of both views.
+type sectionKey struct {
+	view config.ViewType
+	id   int
+}
+
+// cursorTarget is where the cursor of a section goes once its rows are
+// fetched, see restoreCursor.
+type cursorTarget struct {
+	id       string
+	position int
+}
+
+// loadState reads the state saved when the program last quit. The app starts
+// from the defaults of the config when there is none or it cannot be read.
+func loadState() (*state.State, string) {
+	path, err := state.DefaultPath()
+	if err != nil {
+		log.Printf("Session state disabled: %v\n", err)
+		return nil, ""
+	}
+
+	saved, err := state.Load(path)
+	if err != nil {
+		log.Printf("Ignoring the session state: %v\n", err)
+		return nil, path
+	}
+	return saved, path
+}
+
+// restoreState starts from the view, selected sections and preview saved
+// when the program last quit.
+func (m *Model) restoreState(saved *state.State) {
+	m.savedState = saved
+	if saved == nil {
+		return
+	}
+
+	if view := config.ViewType(saved.View); view == config.PlaceholderView || view == config.OtherView {
+		m.ctx.View = view
+	}
+	m.sidebar.IsOpen = saved.Preview.Open
+	if saved.Preview.Width > 0 && saved.Preview.Width <= 100 {
+		m.previewWidth = m.ctx.Config.Defaults.Preview.GetWidth(saved.Preview.Width)
+	}
+
+	for _, view := range []config.ViewType{config.PlaceholderView, config.OtherView} {
+		viewState, ok := saved.Views[string(view)]
+		if !ok {
+			continue
+		}
+		for i, sectionConfig := range m.ctx.Config.GetViewSections(view) {
+			if sectionConfig.Title == viewState.CurrSection {
+				m.viewSectionIds[view] = i
+				break
+			}
+		}
+	}
+	m.setCurrSectionId(m.viewSectionIds[m.ctx.View])
+}
+
+// restoreSections applies the saved searches to the new sections of view. The
+// saved cursors wait for the rows to be fetched, see restoreCursor.
+func (m *Model) restoreSections(view config.ViewType, sections []section.Section) {
+	if m.savedState == nil {
+		return
+	}
+
+	viewState := m.savedState.Views[string(view)]
+	sectionConfigs := m.ctx.Config.GetViewSections(view)
+	for i, currSection := range sections {
+		if i >= len(sectionConfigs) {
+			break
+		}
+		sectionState, ok := viewState.Sections[sectionConfigs[i].Title]
+		if !ok {
+			continue
+		}
+		currSection.Search(sectionState.Search)
+		if sectionState.Cursor > 0 {
+			m.pendingCursors[sectionKey{view: view, id: currSection.Id()}] = cursorTarget{position: sectionState.Cursor}
+		}
+	}
+}
+
+// keepCursor makes the row under the cursor of a section the target of its
+// cursor, right before the rows of a fetch replace the shown ones. The cursor
+// thus stays on the record the user is on when the rows arrive, wherever it
+// was when the fetch started. Sections without rows keep the saved target.
+func (m *Model) keepCursor(key sectionKey) {
+	sections := m.getViewSections(key.view)
+	if key.id >= len(sections) || sections[key.id].NumRows() == 0 {
+		return
+	}
+	currSection := sections[key.id]
+
+	target := cursorTarget{position: currSection.GetCursor()}
+	if id, ok := pkg.RecordId(currSection.GetCurrRow()); ok {
+		target.id = id
+	}
+	m.pendingCursors[key] = target
+}
+
+// restoreCursor moves the cursor of a section to its target once its rows
+// are fetched: on the record with the target id when it is still there,
+// otherwise at the target position, or the last row when there are fewer of
+// them now. The target is kept for the fresh rows following cached ones.
+func (m *Model) restoreCursor(key sectionKey, result section.FetchResult) {
+	target, ok := m.pendingCursors[key]
+	if !ok || result.Append {
+		return
+	}
+	if !result.Stale {
+		delete(m.pendingCursors, key)
+	}
+
+	sections := m.getViewSections(key.view)
+	if key.id >= len(sections) {
+		return
+	}
+	currSection := sections[key.id]
+	if target.id != "" {
+		records, _ := currSection.GetShownRows()
+		for position, record := range records {
+			if id, ok := pkg.RecordId(record); ok && id == target.id {
+				currSection.SetCursor(position)
+				return
+			}
+		}
+	}
+	currSection.SetCursor(target.position)
+}
+
+// saveState saves the view, sections and preview shown for the next launch.
+// The views that were not shown keep their saved state.
+func (m *Model) saveState() {
+	if m.statePath == "" || m.ctx.Config == nil {
+		return
+	}
+
+	saved := state.State{
+		View:    string(m.ctx.View),
+		Views:   map[string]state.ViewState{},
+		Preview: state.PreviewState{Open: m.sidebar.IsOpen, Width: m.previewWidth},
+	}
+	if m.savedState != nil {
+		for view, viewState := range m.savedState.Views {
+			saved.Views[view] = viewState
+		}
+	}
+
+	m.viewSectionIds[m.ctx.View] = m.currSectionId
+	for _, view := range []config.ViewType{config.PlaceholderView, config.OtherView} {
+		sections := m.getViewSections(view)
+		if len(sections) == 0 {
+			continue
+		}
+
+		sectionConfigs := m.ctx.Config.GetViewSections(view)
+		viewState := state.ViewState{Sections: map[string]state.SectionState{}}
+		if sectionId := m.viewSectionIds[view]; sectionId < len(sectionConfigs) {
+			viewState.CurrSection = sectionConfigs[sectionId].Title
+		}
+		for i, currSection := range sections {
+			if i >= len(sectionConfigs) {
+				break
+			}
+			cursor := currSection.GetCursor()
+			if target, ok := m.pendingCursors[sectionKey{view: view, id: currSection.Id()}]; ok {
+				cursor = target.position
+			}
+			viewState.Sections[sectionConfigs[i].Title] = state.SectionState{Cursor: cursor, Search: currSection.GetSearch()}
+		}
+		saved.Views[string(view)] = viewState
+	}
+
+	if err := state.Save(m.statePath, saved); err != nil {
+		log.Printf("Could not save the session state: %v\n", err)
+	}
+}
@@ -0,0 +1,54 @@
+package ui
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/mehmetcantas/medium-cli/config"
+	"github.com/mehmetcantas/medium-cli/pkg/state"
+)
+
+func TestPreviewWidthState(t *testing.T) {
+	configWidth := 40
+	tests := []struct {
+		name        string
+		configWidth *int
+		savedWidth  int
+		want        int
+	}{
+		{name: "default", want: config.DefaultPreviewWidth},
+		{name: "saved", savedWidth: 30, want: 30},
+		{name: "out of range saved", savedWidth: 130, want: config.DefaultPreviewWidth},
+		{name: "config", configWidth: &configWidth, want: 40},
+		{name: "config wins over saved", configWidth: &configWidth, savedWidth: 30, want: 40},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "state.json")
+			var saved *state.State
+			if test.savedWidth > 0 {
+				saved = &state.State{Preview: state.PreviewState{Open: true, Width: test.savedWidth}}
+			}
+			sectionConfig := config.SectionConfig{Title: "Posts", Filters: "posts"}
+			cfg := config.Config{PlaceholderSections: []config.SectionConfig{sectionConfig}}
+			cfg.Defaults.Preview = config.PreviewConfig{Open: true, Width: test.configWidth}
+
+			m := NewModel(Options{})
+			updated, _ := m.Update(initMsg{Config: cfg, State: saved, StatePath: path})
+			m = *updated.(*Model)
+			if m.previewWidth != test.want {
+				t.Errorf("preview width = %d, want %d", m.previewWidth, test.want)
+			}
+
+			m.saveState()
+			saved, err := state.Load(path)
+			if err != nil || saved == nil {
+				t.Fatalf("state.Load() = %v, %v", saved, err)
+			}
+			if saved.Preview.Width != test.want {
+				t.Errorf("saved preview width = %d, want %d", saved.Preview.Width, test.want)
+			}
+		})
+	}
+}
@@ -1,31 +1,115 @@
 package ui
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/mehmetcantas/medium-cli/components/export"
 	"github.com/mehmetcantas/medium-cli/components/help"
 	"github.com/mehmetcantas/medium-cli/components/placeholdersection"
+	"github.com/mehmetcantas/medium-cli/components/reader"
+	"github.com/mehmetcantas/medium-cli/components/search"
 	"github.com/mehmetcantas/medium-cli/components/section"
+	"github.com/mehmetcantas/medium-cli/components/sidebar"
 	"github.com/mehmetcantas/medium-cli/components/tabs"
 	"github.com/mehmetcantas/medium-cli/config"
 	"github.com/mehmetcantas/medium-cli/pkg"
+	"github.com/mehmetcantas/medium-cli/pkg/httpcache"
+	"github.com/mehmetcantas/medium-cli/pkg/state"
 	"github.com/mehmetcantas/medium-cli/ui/screencontext"
 )
 
+// offlineAfterFailures is the number of consecutive fetches failing to reach
+// the server after which the app switches to offline mode by itself.
+const offlineAfterFailures = 2
+
+var (
+	errorTitleStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.AdaptiveColor{Light: "#c0392b", Dark: "#e74c3c"}).
+			Padding(1, 1, 0, 1)
+
+	errorLocationStyle = lipgloss.NewStyle().
+				Faint(true).
+				PaddingLeft(3)
+
+	errorMessageStyle = lipgloss.NewStyle().
+				PaddingLeft(5)
+
+	errorHintStyle = lipgloss.NewStyle().
+			Faint(true).
+			Padding(1, 1)
+)
+
 type Model struct {
 	tabs          tabs.Model
 	ctx           screencontext.ScreenContext
-	keys          pkg.KeyMap
 	placeholders  []section.Section
+	others        []section.Section
 	err           error
 	currSectionId int
-	help          help.Model
+	// viewSectionIds remembers the selected section of each view so switching
+	// back and forth keeps the user's position.
+	viewSectionIds map[config.ViewType]int
+	sidebar        sidebar.Model
+	reader         reader.Model
+	search         search.Model
+	export         export.Model
+	help           help.Model
+	options        Options
+	cancelFetches  context.CancelFunc
+	// connectionFailures counts the fetches that could not reach the server
+	// since the last successful one.
+	connectionFailures int
+	launcher           pkg.Launcher
+	// savedState is the state saved when the program last quit, nil when
+	// there is none, and statePath where it is saved again on quit.
+	savedState *state.State
+	statePath  string
+	// previewWidth is the width of the preview in percent of the screen, the
+	// one of the config file, else the saved one, else the default one.
+	previewWidth int
+	// pendingCursors are where the cursors of the sections go once their rows
+	// are fetched.
+	pendingCursors map[sectionKey]cursorTarget
+	// isUnfocused is set while the terminal reports it lost the focus.
+	isUnfocused bool
+	// refreshTickIds are the ids of the last refresh tick scheduled for each
+	// section, older ticks are dropped.
+	refreshTickIds map[sectionKey]int
+	// refreshesDue are the sections whose refresh came while refreshing was
+	// paused.
+	refreshesDue map[sectionKey]bool
 }
+
+// Options hold the command line settings, which take precedence over the
+// config file.
+type Options struct {
+	ConfigPath string
+	NoCache    bool
+	Offline    bool
+	// Launcher opens URLs, pkg.OpenInBrowser when nil.
+	Launcher pkg.Launcher
+}
+
 type initMsg struct {
-	Config config.Config
+	Config    config.Config
+	Sources   map[string]config.Source
+	Keys      pkg.KeyMap
+	Cache     *httpcache.Cache
+	State     *state.State
+	StatePath string
+}
+
+type urlOpenedMsg struct {
+	url string
+	err error
 }
 
 type errMsg struct {
@@ -34,31 +118,69 @@ type errMsg struct {
 
 func (e errMsg) Error() string { return e.error.Error() }
 
-func NewModel() Model {
+func NewModel(options Options) Model {
 	tabsModel := tabs.NewModel()
+	fetchCtx, cancelFetches := context.WithCancel(context.Background())
+	launcher := options.Launcher
+	if launcher == nil {
+		launcher = pkg.OpenInBrowser
+	}
 	return Model{
-		keys:          pkg.Keys,
-		currSectionId: 0,
-		help:          help.NewModel(),
-		tabs:          tabsModel,
+		ctx:            screencontext.ScreenContext{Context: fetchCtx, Offline: options.Offline, Keys: pkg.Keys},
+		cancelFetches:  cancelFetches,
+		currSectionId:  0,
+		help:           help.NewModel(),
+		sidebar:        sidebar.NewModel(),
+		reader:         reader.NewModel(),
+		search:         search.NewModel(),
+		export:         export.NewModel(),
+		tabs:           tabsModel,
+		options:        options,
+		launcher:       launcher,
+		viewSectionIds: map[config.ViewType]int{},
+		pendingCursors: map[sectionKey]cursorTarget{},
+		refreshTickIds: map[sectionKey]int{},
+		refreshesDue:   map[sectionKey]bool{},
 	}
 }
-func initScreen() tea.Msg {
-	settings, err := config.ParseConfig()
+func (m *Model) initScreen() tea.Msg {
+	settings, err := config.ParseConfig(m.options.ConfigPath)
+	if err != nil {
+		return errMsg{err}
+	}
+	if m.options.NoCache {
+		settings.Cache.Enabled = false
+	}
+	sources, err := settings.ResolveSources()
+	if err != nil {
+		return errMsg{err}
+	}
+	keys, err := settings.GetKeyMap()
 	if err != nil {
 		return errMsg{err}
 	}
 
-	return initMsg{Config: settings}
+	saved, statePath := loadState()
+
+	return initMsg{
+		Config:    settings,
+		Sources:   sources,
+		Keys:      keys,
+		Cache:     openCache(settings.Cache),
+		State:     saved,
+		StatePath: statePath,
+	}
 }
 func (m Model) Init() tea.Cmd {
-	return tea.Batch(initScreen, tea.EnterAltScreen)
+	return tea.Batch(m.initScreen, tea.EnterAltScreen)
 }
 
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var (
 		cmd         tea.Cmd
 		sidebarCmd  tea.Cmd
+		searchCmd   tea.Cmd
+		exportCmd   tea.Cmd
 		helpCmd     tea.Cmd
 		cmds        []tea.Cmd
 		currSection = m.getCurrSection()
@@ -66,63 +188,187 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if focused, ok := pkg.ParseFocusReport(msg); ok {
+			m.isUnfocused = !focused
+			break
+		}
+		if m.ctx.Config == nil {
+			if key.Matches(msg, m.ctx.Keys.Quit) {
+				cmd = m.quit()
+			}
+			break
+		}
+		if m.reader.IsOpen {
+			cmd = m.updateReader(msg)
+			break
+		}
+		if m.search.IsEditing {
+			cmd = m.updateSearch(msg)
+			break
+		}
+		if m.export.IsEditing {
+			cmd = m.updateExport(msg)
+			break
+		}
+
 		switch {
-		case key.Matches(msg, m.keys.PrevSection):
+		case key.Matches(msg, m.ctx.Keys.PrevSection):
 			prevSection := m.getSectionAt(m.getPrevSectionId())
 			if prevSection != nil {
 				m.setCurrSectionId(prevSection.Id())
 				m.onViewedRowChanged()
 			}
 
-		case key.Matches(msg, m.keys.NextSection):
+		case key.Matches(msg, m.ctx.Keys.NextSection):
 			nextSectionId := m.getNextSectionId()
 			nextSection := m.getSectionAt(nextSectionId)
 			if nextSection != nil {
 				m.setCurrSectionId(nextSection.Id())
 				m.onViewedRowChanged()
 			}
-		case key.Matches(msg, m.keys.Up):
-			currSection.PrevRow()
-			m.onViewedRowChanged()
+		case key.Matches(msg, m.ctx.Keys.Up):
+			if currSection != nil {
+				currSection.PrevRow()
+				m.onCursorMoved()
+			}
 
-		case key.Matches(msg, m.keys.Down):
-			currSection.NextRow()
+		case key.Matches(msg, m.ctx.Keys.Down):
+			if currSection != nil {
+				currSection.NextRow()
+				m.onCursorMoved()
+				cmd = currSection.FetchNextPageRows()
+			}
+		case key.Matches(msg, m.ctx.Keys.TogglePreview):
+			m.sidebar.IsOpen = !m.sidebar.IsOpen
+			m.syncMainContentWidth()
 			m.onViewedRowChanged()
-		case key.Matches(msg, m.keys.Quit):
-			cmd = tea.Quit
-
-		case key.Matches(msg, m.keys.SwitchView):
+		case key.Matches(msg, m.ctx.Keys.OpenInBrowser):
+			cmd = m.openCurrRowInBrowser()
+		case key.Matches(msg, m.ctx.Keys.Read):
+			cmd = m.openCurrRowInReader()
+		case key.Matches(msg, m.ctx.Keys.SortColumn):
+			if currSection != nil {
+				currSection.NextSortColumn()
+				m.onViewedRowChanged()
+			}
+		case key.Matches(msg, m.ctx.Keys.SortDirection):
+			if currSection != nil {
+				currSection.FlipSortDirection()
+				m.onViewedRowChanged()
+			}
+		case key.Matches(msg, m.ctx.Keys.Search):
+			if currSection != nil {
+				cmd = m.search.Open(currSection.GetSearch())
+			}
+		case key.Matches(msg, m.ctx.Keys.Export):
+			if currSection != nil {
+				cmd = m.export.Open(m.defaultExportPath())
+			}
+		case key.Matches(msg, m.ctx.Keys.Back):
+			if currSection != nil && currSection.GetSearch() != "" {
+				currSection.Search("")
+				m.onViewedRowChanged()
+			}
+		case key.Matches(msg, m.ctx.Keys.PageDown):
+			m.sidebar.ScrollDown()
+		case key.Matches(msg, m.ctx.Keys.PageUp):
+			m.sidebar.ScrollUp()
+		case key.Matches(msg, m.ctx.Keys.Quit):
+			cmd = m.quit()
+		case key.Matches(msg, m.ctx.Keys.Help):
+			m.help.ToggleShowAll()
+
+		case key.Matches(msg, m.ctx.Keys.SwitchView):
+			m.viewSectionIds[m.ctx.View] = m.currSectionId
 			m.ctx.View = m.switchSelectedView()
 			m.syncMainContentWidth()
-			m.setCurrSectionId(0)
+			m.setCurrSectionId(m.viewSectionIds[m.ctx.View])
 
 			currSections := m.getCurrentViewSections()
 			if len(currSections) == 0 {
 				newSections, fetchSectionsCmds := m.fetchAllViewSections()
 				m.setCurrentViewSections(newSections)
+				m.restoreSections(m.ctx.View, newSections)
 				cmd = fetchSectionsCmds
 			}
 			m.onViewedRowChanged()
-		case key.Matches(msg, m.keys.Refresh):
-			cmd = currSection.FetchSectionRows()
-
+		case key.Matches(msg, m.ctx.Keys.Refresh):
+			if m.ctx.Offline {
+				cmd = m.help.SetStatus(
+					fmt.Sprintf("Offline: showing cached data, press %s to go online and refresh", m.ctx.Keys.ToggleOffline.Help().Key),
+					false,
+				)
+				break
+			}
+			cmd = m.refreshSection(sectionKey{view: m.ctx.View, id: m.currSectionId})
+		case key.Matches(msg, m.ctx.Keys.NextNew):
+			if currSection == nil {
+				break
+			}
+			if !currSection.NextNewRow() {
+				cmd = m.help.SetStatus("No new rows", false)
+				break
+			}
+			m.onCursorMoved()
+			cmd = currSection.FetchNextPageRows()
+		case key.Matches(msg, m.ctx.Keys.ToggleOffline):
+			cmd = m.setOffline(!m.ctx.Offline)
+		default:
+			if currSection != nil {
+				cmd = m.runCurrSectionAction(msg)
+			}
 		}
 	case initMsg:
 		m.ctx.Config = &msg.Config
+		m.ctx.Cache = msg.Cache
+		m.ctx.Sources = msg.Sources
+		m.ctx.Keys = msg.Keys
 		m.ctx.View = m.ctx.Config.Defaults.View
+		m.sidebar.IsOpen = m.ctx.Config.Defaults.Preview.Open
+		m.previewWidth = m.ctx.Config.Defaults.Preview.GetWidth(config.DefaultPreviewWidth)
+		m.statePath = msg.StatePath
+		m.restoreState(msg.State)
 		m.syncMainContentWidth()
 		newSections, fetchSectionsCmds := m.fetchAllViewSections()
 		m.setCurrentViewSections(newSections)
+		m.restoreSections(m.ctx.View, newSections)
 		cmd = fetchSectionsCmds
 	case section.SectionMsg:
+		key := sectionKey{view: msg.GetSectionView(), id: msg.GetSectionId()}
+		fetchedMsg, isFetched := msg.(section.FetchedMsg)
+		if isFetched && !fetchedMsg.GetFetchResult().Append {
+			m.keepCursor(key)
+		}
 		cmd = m.updateRelevantSection(msg)
-
-		if msg.GetSectionId() == m.currSectionId {
-			switch msg.GetSectionType() {
-			case placeholdersection.SectionType:
-				m.onViewedRowChanged()
+		if isFetched {
+			result := fetchedMsg.GetFetchResult()
+			cmds = append(cmds, m.onFetchResult(result))
+			m.restoreCursor(key, result)
+			if !result.Stale && !result.Append {
+				cmds = append(cmds, m.scheduleRefresh(key))
+				if result.Err != nil {
+					cmds = append(cmds, m.showRefreshError(key, result.Err))
+				} else {
+					cmds = append(cmds, m.showChanges(key))
+				}
 			}
 		}
+
+		if msg.GetSectionView() == m.ctx.View && msg.GetSectionId() == m.currSectionId {
+			m.onViewedRowChanged()
+		}
+	case refreshTickMsg:
+		cmd = m.onRefreshTick(msg)
+	case actionFinishedMsg:
+		cmd = m.onActionFinished(msg)
+	case exportFinishedMsg:
+		cmd = m.onExportFinished(msg)
+	case urlOpenedMsg:
+		if msg.err != nil {
+			cmd = m.help.SetStatus(fmt.Sprintf("Could not open %s: %v", msg.url, msg.err), true)
+		} else {
+			cmd = m.help.SetStatus(fmt.Sprintf("Opened %s", msg.url), false)
+		}
 	case tea.WindowSizeMsg:
 		m.onWindowSizeChanged(msg)
 
@@ -132,45 +378,310 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	m.syncProgramContext()
 	m.help, helpCmd = m.help.Update(msg)
-	cmds = append(cmds, cmd, sidebarCmd, helpCmd)
+	// Keys reach the search and export prompts in updateSearch and
+	// updateExport, other messages make their cursor blink.
+	if _, ok := msg.(tea.KeyMsg); !ok {
+		m.search, searchCmd = m.search.Update(msg)
+		m.export, exportCmd = m.export.Update(msg)
+	}
+	cmds = append(cmds, cmd, sidebarCmd, helpCmd, searchCmd, exportCmd, m.refreshDueSections())
 	return &m, tea.Batch(cmds...)
 }
 
 func (m Model) View() string {
 	if m.err != nil {
-		return m.err.Error()
+		return m.renderError()
 	}
 
 	if m.ctx.Config == nil {
 		return "Reading config...\n"
 	}
 
+	if m.reader.IsOpen {
+		return m.reader.View()
+	}
+
 	s := strings.Builder{}
 	s.WriteString(m.tabs.View(m.ctx))
 	s.WriteString("\n")
 	currSection := m.getCurrSection()
+	if m.isSearchShown() {
+		s.WriteString(m.search.View(m.ctx, currSection.GetSearch()))
+		s.WriteString("\n")
+	}
 	mainContent := ""
 	if currSection != nil {
 		mainContent = lipgloss.JoinHorizontal(
 			lipgloss.Top,
-			m.getCurrSection().View(),
+			currSection.View(),
+			m.sidebar.View(),
 		)
 	} else {
-		mainContent = "No data found"
+		mainContent = m.renderNoSections()
 	}
 	s.WriteString(mainContent)
 	s.WriteString("\n")
-	s.WriteString(m.help.View(m.ctx))
+	if m.export.IsEditing {
+		s.WriteString(m.export.View(m.ctx))
+	} else {
+		s.WriteString(m.help.View(m.ctx))
+	}
 	return s.String()
 }
 
+// renderNoSections fills the main content of a view without sections.
+func (m Model) renderNoSections() string {
+	key := "placeholderSections"
+	if m.ctx.View == config.OtherView {
+		key = "otherSections"
+	}
+	return section.EmptyStateStyle.Copy().
+		MarginBottom(0).
+		Height(m.ctx.MainContentHeight).
+		Render(fmt.Sprintf("No sections in this view, add some under %s in the config file", key))
+}
+
+func (m Model) renderError() string {
+	quitKey := m.ctx.Keys.Quit.Help().Key
+	var validationErr config.ValidationError
+	if !errors.As(m.err, &validationErr) {
+		return lipgloss.JoinVertical(
+			lipgloss.Left,
+			errorTitleStyle.Render("Something went wrong"),
+			errorMessageStyle.Render(m.err.Error()),
+			errorHintStyle.Render(fmt.Sprintf("Press %s to quit", quitKey)),
+		)
+	}
+
+	lines := []string{
+		errorTitleStyle.Render(fmt.Sprintf("Invalid configuration (%d problems)", len(validationErr.Problems))),
+	}
+	for _, problem := range validationErr.Problems {
+		lines = append(
+			lines,
+			"",
+			errorLocationStyle.Render(problem.Location(validationErr.File)),
+			errorMessageStyle.Render(problem.Message),
+		)
+	}
+	lines = append(lines, errorHintStyle.Render(fmt.Sprintf("Fix the config file and restart, press %s to quit", quitKey)))
+
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
+
+// openCache returns the response cache, or nil when it is disabled or cannot be
+// created; the app then simply works without it.
+func openCache(cacheConfig config.CacheConfig) *httpcache.Cache {
+	if !cacheConfig.Enabled {
+		return nil
+	}
+
+	dir, err := httpcache.DefaultDir()
+	if err == nil {
+		var cache *httpcache.Cache
+		cache, err = httpcache.New(dir, int64(cacheConfig.MaxSizeMB)*1024*1024)
+		if err == nil {
+			return cache
+		}
+	}
+
+	log.Printf("Response cache disabled: %v\n", err)
+	return nil
+}
+
+// quit saves the session state and cancels every request still in flight so a
+// hanging server cannot keep the program alive.
+func (m *Model) quit() tea.Cmd {
+	m.saveState()
+	m.cancelFetches()
+	return tea.Quit
+}
+
+// onFetchResult switches to offline mode once fetches keep failing to reach
+// the server.
+func (m *Model) onFetchResult(result section.FetchResult) tea.Cmd {
+	if m.ctx.Offline {
+		return nil
+	}
+
+	switch {
+	case placeholdersection.IsConnectionError(result.Err):
+		m.connectionFailures++
+	case result.Err == nil && !result.Stale:
+		m.connectionFailures = 0
+	}
+
+	if m.connectionFailures < offlineAfterFailures {
+		return nil
+	}
+
+	log.Printf("%d fetches could not reach the server, switching to offline mode\n", m.connectionFailures)
+	return m.setOffline(true)
+}
+
+// setOffline switches offline mode and fetches every section again, from the
+// cache only when going offline.
+func (m *Model) setOffline(offline bool) tea.Cmd {
+	m.ctx.Offline = offline
+	m.connectionFailures = 0
+
+	var status string
+	if offline {
+		status = fmt.Sprintf("Offline: showing cached data, press %s to go back online", m.ctx.Keys.ToggleOffline.Help().Key)
+	} else {
+		status = "Back online, refreshing"
+	}
+	cmds := []tea.Cmd{m.help.SetStatus(status, false)}
+	for _, view := range []config.ViewType{config.PlaceholderView, config.OtherView} {
+		for _, section := range m.getViewSections(view) {
+			section.UpdateScreenContext(&m.ctx)
+			cmds = append(cmds, section.FetchSectionRows())
+		}
+	}
+
+	return tea.Batch(cmds...)
+}
+
+// openCurrRowInBrowser opens the URL built from the section's urlTemplate and
+// the selected row.
+func (m *Model) openCurrRowInBrowser() tea.Cmd {
+	currSection := m.getCurrSection()
+	sectionConfigs := m.ctx.GetViewSectionsConfig()
+	if currSection == nil || m.currSectionId >= len(sectionConfigs) {
+		return nil
+	}
+	sectionConfig := sectionConfigs[m.currSectionId]
+
+	if sectionConfig.URLTemplate == "" {
+		return m.help.SetStatus(fmt.Sprintf("Section %q has no urlTemplate to open", sectionConfig.Title), true)
+	}
+	record := currSection.GetCurrRow()
+	if record == nil {
+		return m.help.SetStatus("Nothing selected to open", true)
+	}
+	url, err := sectionConfig.BuildURL(record)
+	if err != nil {
+		return m.help.SetStatus(fmt.Sprintf("Could not build the URL of section %q: %v", sectionConfig.Title, err), true)
+	}
+
+	launcher := m.launcher
+	return func() tea.Msg {
+		return urlOpenedMsg{url: url, err: launcher(url)}
+	}
+}
+
+// openCurrRowInReader shows the selected row full screen when it is an
+// article.
+func (m *Model) openCurrRowInReader() tea.Cmd {
+	currSection := m.getCurrSection()
+	if currSection == nil {
+		return nil
+	}
+
+	article, ok := currSection.GetCurrRow().(reader.Article)
+	if !ok {
+		return m.help.SetStatus("Nothing to read in this row", true)
+	}
+	m.reader.Open(article)
+	return nil
+}
+
+// updateSearch handles the keys while the search is edited, searching the
+// rows of the current section as the query is typed.
+func (m *Model) updateSearch(msg tea.KeyMsg) tea.Cmd {
+	currSection := m.getCurrSection()
+	if currSection == nil {
+		m.search.Close()
+		return nil
+	}
+
+	switch {
+	case m.isPromptQuit(msg):
+		return m.quit()
+	case key.Matches(msg, m.ctx.Keys.Back):
+		m.search.Close()
+		currSection.Search("")
+	case msg.Type == tea.KeyEnter:
+		m.search.Close()
+	case msg.Type == tea.KeyUp:
+		currSection.PrevRow()
+		m.onCursorMoved()
+		return nil
+	case msg.Type == tea.KeyDown:
+		currSection.NextRow()
+		m.onCursorMoved()
+		return currSection.FetchNextPageRows()
+	default:
+		var cmd tea.Cmd
+		m.search, cmd = m.search.Update(msg)
+		if m.search.Value() != currSection.GetSearch() {
+			currSection.Search(m.search.Value())
+		}
+		m.onViewedRowChanged()
+		return cmd
+	}
+
+	m.onViewedRowChanged()
+	return nil
+}
+
+// isPromptQuit reports whether msg quits the program while a prompt is open.
+// Quit keys typing text, such as q, go to the prompt instead.
+func (m *Model) isPromptQuit(msg tea.KeyMsg) bool {
+	return key.Matches(msg, m.ctx.Keys.Quit) && msg.Type != tea.KeyRunes && msg.Type != tea.KeySpace
+}
+
+// isSearchShown reports whether the search bar takes a line above the table,
+// while the search is edited or applied to the current section.
+func (m *Model) isSearchShown() bool {
+	currSection := m.getCurrSection()
+	return currSection != nil && (m.search.IsEditing || currSection.GetSearch() != "")
+}
+
+// updateReader handles the keys while the reader is open. The section's
+// cursor does not move, so going back lands on the row the article was opened
+// from.
+func (m *Model) updateReader(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, m.ctx.Keys.Back):
+		m.reader.Close()
+	case key.Matches(msg, m.ctx.Keys.Up):
+		m.reader.LineUp()
+	case key.Matches(msg, m.ctx.Keys.Down):
+		m.reader.LineDown()
+	case key.Matches(msg, m.ctx.Keys.PageUp):
+		m.reader.PageUp()
+	case key.Matches(msg, m.ctx.Keys.PageDown):
+		m.reader.PageDown()
+	case key.Matches(msg, m.ctx.Keys.Quit):
+		return m.quit()
+	}
+
+	return nil
+}
+
 func (m *Model) setCurrSectionId(newSectionId int) {
 	m.currSectionId = newSectionId
 	m.tabs.SetCurrSectionId(newSectionId)
 }
 
 func (m *Model) onViewedRowChanged() {
+	currSection := m.getCurrSection()
+	if currSection == nil {
+		m.sidebar.SetRecord(nil)
+		return
+	}
+	m.sidebar.SetRecord(currSection.GetCurrRow())
+}
 
+// onCursorMoved marks the row the user moved the cursor to as read, then
+// shows it. Rows only get under the cursor otherwise, e.g. when fetched rows
+// replace the shown ones, and keep their marks then.
+func (m *Model) onCursorMoved() {
+	if currSection := m.getCurrSection(); currSection != nil {
+		currSection.MarkCurrRowRead()
+	}
+	m.onViewedRowChanged()
 }
 func (m *Model) getSectionAt(id int) section.Section {
 	sections := m.getCurrentViewSections()
@@ -183,22 +694,41 @@ func (m *Model) onWindowSizeChanged(msg tea.WindowSizeMsg) {
 	m.help.SetWidth(msg.Width)
 	m.ctx.ScreenWidth = msg.Width
 	m.ctx.ScreenHeight = msg.Height
-	m.ctx.MainContentHeight = msg.Height - tabs.TabsHeight - help.FooterHeight
 	m.syncMainContentWidth()
 }
 
 func (m *Model) syncProgramContext() {
+	m.syncMainContentHeight()
 	for _, section := range m.getCurrentViewSections() {
 		section.UpdateScreenContext(&m.ctx)
 	}
+	if currSection := m.getCurrSection(); currSection != nil {
+		m.tabs.SetDataFetchedAt(currSection.GetFetchedAt())
+	}
+	m.tabs.SetUnreadCounts(m.getUnreadCounts())
+	m.sidebar.UpdateScreenContext(&m.ctx)
+	m.reader.UpdateScreenContext(&m.ctx)
+	m.help.SetActions(m.getActionBindings())
 }
+func (m *Model) syncMainContentHeight() {
+	m.ctx.MainContentHeight = m.ctx.ScreenHeight - tabs.TabsHeight - help.FooterHeight
+	if m.isSearchShown() {
+		m.ctx.MainContentHeight -= search.SearchHeight
+	}
+}
+
 func (m *Model) syncMainContentWidth() {
-	m.ctx.MainContentWidth = m.ctx.ScreenWidth
+	sideBarOffset := 0
+	if m.sidebar.IsOpen && m.ctx.Config != nil {
+		sideBarOffset = m.ctx.ScreenWidth * m.previewWidth / 100
+	}
+	m.ctx.MainContentWidth = m.ctx.ScreenWidth - sideBarOffset
 }
 
+// getCurrSection returns the selected section, or nil when the view has none.
 func (m *Model) getCurrSection() section.Section {
 	sections := m.getCurrentViewSections()
-	if len(sections) == 0 {
+	if m.currSectionId < 0 || m.currSectionId >= len(sections) {
 		return nil
 	}
 	return sections[m.currSectionId]
@@ -206,6 +736,9 @@ func (m *Model) getCurrSection() section.Section {
 
 func (m *Model) getPrevSectionId() int {
 	sectionsConfigs := m.ctx.GetViewSectionsConfig()
+	if len(sectionsConfigs) == 0 {
+		return 0
+	}
 	m.currSectionId = (m.currSectionId - 1) % len(sectionsConfigs)
 	if m.currSectionId < 0 {
 		m.currSectionId += len(sectionsConfigs)
@@ -215,45 +748,61 @@ func (m *Model) getPrevSectionId() int {
 }
 
 func (m *Model) getNextSectionId() int {
-	return (m.currSectionId + 1) % len(m.ctx.GetViewSectionsConfig())
+	sectionsConfigs := m.ctx.GetViewSectionsConfig()
+	if len(sectionsConfigs) == 0 {
+		return 0
+	}
+	return (m.currSectionId + 1) % len(sectionsConfigs)
 }
 
 func (m *Model) getCurrentViewSections() []section.Section {
-	if m.ctx.View == config.PlaceholderView {
-		return m.placeholders
-	} else {
-		return []section.Section{}
+	return m.getViewSections(m.ctx.View)
+}
+
+func (m *Model) getViewSections(view config.ViewType) []section.Section {
+	if view == config.OtherView {
+		return m.others
 	}
+
+	return m.placeholders
 }
+
 func (m *Model) fetchAllViewSections() ([]section.Section, tea.Cmd) {
-	if m.ctx.View == config.PlaceholderView {
-		return placeholdersection.FetchAllSections(m.ctx)
-	} else {
-		return []section.Section{}, nil
+	ctx := m.ctx
+	sectionConfigs := ctx.GetViewSectionsConfig()
+	fetchSectionsCmds := make([]tea.Cmd, 0, len(sectionConfigs))
+	sections := make([]section.Section, 0, len(sectionConfigs))
+	for i, sectionConfig := range sectionConfigs {
+		sectionModel := newSection(i, &ctx, sectionConfig, ctx.View)
+		sections = append(sections, sectionModel)
+		fetchSectionsCmds = append(fetchSectionsCmds, sectionModel.FetchSectionRows())
 	}
 
+	return sections, tea.Batch(fetchSectionsCmds...)
 }
+
 func (m *Model) setCurrentViewSections(newSections []section.Section) {
-	if m.ctx.View == config.PlaceholderView {
+	if m.ctx.View == config.OtherView {
+		m.others = newSections
+	} else {
 		m.placeholders = newSections
 	}
 }
 
 func (m *Model) updateRelevantSection(msg section.SectionMsg) (cmd tea.Cmd) {
-	var updatedSection section.Section
-
-	switch msg.GetSectionType() {
-	case placeholdersection.SectionType:
-		updatedSection, cmd = m.placeholders[msg.GetSectionId()].Update(msg)
-		m.placeholders[msg.GetSectionId()] = updatedSection
+	sections := m.getViewSections(msg.GetSectionView())
+	if msg.GetSectionId() >= len(sections) {
+		return nil
 	}
 
+	sections[msg.GetSectionId()], cmd = sections[msg.GetSectionId()].Update(msg)
 	return cmd
 }
+
 func (m *Model) switchSelectedView() config.ViewType {
 	if m.ctx.View == config.PlaceholderView {
-		return config.PlaceholderView
-	} else {
 		return config.OtherView
+	} else {
+		return config.PlaceholderView
 	}
 }